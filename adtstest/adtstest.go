@@ -0,0 +1,87 @@
+// Package adtstest provides conformance test suites for implementations of
+// the adts.Container, listadts.List, stackadts.Stack and queueadts.Queue
+// interfaces. Each suite takes a constructor for the implementation under
+// test and runs the full contract against fresh instances of it, including
+// randomized testing against a simple reference model.
+package adtstest
+
+import (
+	"math/rand"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// Option configures a conformance suite.
+type Option func(*config)
+
+type config struct {
+	threadSafe bool
+	seed       int64
+	ops        int
+	workers    int
+}
+
+// ThreadSafe tells the suite that the implementation is safe for concurrent
+// use, which enables the concurrent tests.
+func ThreadSafe() Option {
+	return func(c *config) {
+		c.threadSafe = true
+	}
+}
+
+// Seed sets the seed used for the randomized tests.
+func Seed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+// Ops sets the number of random operations run by the randomized tests.
+func Ops(n int) Option {
+	return func(c *config) {
+		c.ops = n
+	}
+}
+
+// makeConfig applies the given options on top of the defaults.
+func makeConfig(opts []Option) *config {
+	c := &config{false, 99, 500, 8}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// rng returns a new random source for the suite.
+func (c *config) rng() *rand.Rand {
+	return rand.New(rand.NewSource(c.seed))
+}
+
+// Elt is the element type used by the suites. It's an int so that the
+// failure messages are easy to read.
+type Elt int
+
+// Equals returns true if the given container element is the same as this.
+func (e Elt) Equals(other adts.ContainerElement) bool {
+	if o, ok := other.(Elt); ok {
+		return e == o
+	}
+	return false
+}
+
+// clientSeeds draws one seed per linearizability client, so that each client
+// goroutine can use its own random source.
+func clientSeeds(r *rand.Rand) []int64 {
+	seeds := make([]int64, linearizabilityClients)
+	for c := range seeds {
+		seeds[c] = r.Int63()
+	}
+
+	return seeds
+}
+
+// seededRand returns a new random source with the given seed.
+func seededRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
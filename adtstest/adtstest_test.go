@@ -0,0 +1,21 @@
+package adtstest
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestSliceContainerConformance(t *testing.T) {
+	RunContainerSuite(t, func() adts.Container { return adts.MakeSliceContainer() })
+}
+
+func TestSliceContainerThreadSafeConformance(t *testing.T) {
+	RunContainerSuite(t, func() adts.Container { return adts.MakeSliceContainerThreadSafe() }, ThreadSafe())
+}
+
+func TestSyncContainerConformance(t *testing.T) {
+	RunContainerSuite(t, func() adts.Container {
+		return adts.Synchronized(adts.MakeSliceContainer())
+	}, ThreadSafe())
+}
@@ -0,0 +1,187 @@
+package adtstest
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// RunContainerSuite runs the adts.Container contract against containers
+// created by makeContainer. Containers don't promise any order, so they're
+// checked against a multiset model.
+func RunContainerSuite(t *testing.T, makeContainer func() adts.Container, opts ...Option) {
+	cfg := makeConfig(opts)
+
+	t.Run("Empty", func(t *testing.T) {
+		testEmpty(t, makeContainer())
+	})
+	t.Run("AddContains", func(t *testing.T) {
+		c := makeContainer()
+		for i := 0; i < 100; i++ {
+			if !c.Add(Elt(i)) {
+				t.Fatalf("Add(%d) returned false", i)
+			}
+			if c.Len() != i+1 {
+				t.Fatalf("Len after %d adds = %d", i+1, c.Len())
+			}
+			if c.IsEmpty() {
+				t.Fatalf("IsEmpty after %d adds = true", i+1)
+			}
+		}
+		for i := 0; i < 100; i++ {
+			if !c.Contains(Elt(i)) {
+				t.Fatalf("Contains(%d) = false after adding it", i)
+			}
+		}
+		if c.Contains(Elt(100)) {
+			t.Fatal("Contains(100) = true but it was never added")
+		}
+	})
+	t.Run("RemoveOneOccurrence", func(t *testing.T) {
+		c := makeContainer()
+		c.Add(Elt(1))
+		c.Add(Elt(2))
+		c.Add(Elt(1))
+		if !c.Remove(Elt(1)) {
+			t.Fatal("Remove(1) = false but 1 is in the container")
+		}
+		if c.Len() != 2 || !c.Contains(Elt(1)) {
+			t.Fatalf("Remove should only remove one occurrence. Len = %d, Contains(1) = %v", c.Len(), c.Contains(Elt(1)))
+		}
+		if !c.Remove(Elt(1)) || c.Contains(Elt(1)) {
+			t.Fatal("Second Remove(1) should remove the last occurrence")
+		}
+		if c.Remove(Elt(1)) {
+			t.Fatal("Remove(1) = true but 1 is no longer in the container")
+		}
+		if !c.Remove(Elt(2)) || !c.IsEmpty() {
+			t.Fatal("Container should be empty after removing every element")
+		}
+		// Adding after removing everything must still work.
+		c.Add(Elt(3))
+		if c.Len() != 1 || !c.Contains(Elt(3)) {
+			t.Fatal("Add after removing every element failed")
+		}
+	})
+	t.Run("Clear", func(t *testing.T) {
+		c := makeContainer()
+		for i := 0; i < 100; i++ {
+			c.Add(Elt(i))
+		}
+		c.Clear()
+		testEmpty(t, c)
+		c.Add(Elt(1))
+		if c.Len() != 1 || !c.Contains(Elt(1)) {
+			t.Fatal("Add after Clear failed")
+		}
+	})
+	t.Run("Model", func(t *testing.T) {
+		testContainerModel(t, cfg, makeContainer())
+	})
+	if cfg.threadSafe {
+		t.Run("Concurrent", func(t *testing.T) {
+			testContainerConcurrent(t, cfg, makeContainer())
+		})
+	}
+}
+
+// testEmpty checks every Container method against an empty container.
+func testEmpty(t *testing.T, c adts.Container) {
+	t.Helper()
+
+	if c.Len() != 0 {
+		t.Errorf("Len of an empty container = %d", c.Len())
+	}
+	if !c.IsEmpty() {
+		t.Error("IsEmpty of an empty container = false")
+	}
+	if c.Contains(Elt(0)) {
+		t.Error("Contains on an empty container = true")
+	}
+	if c.Remove(Elt(0)) {
+		t.Error("Remove on an empty container = true")
+	}
+}
+
+// testContainerModel runs random operations against the container and a
+// multiset model and checks that they always agree.
+func testContainerModel(t *testing.T, cfg *config, c adts.Container) {
+	r := cfg.rng()
+	model := map[Elt]int{}
+	size := 0
+
+	for i := 0; i < cfg.ops; i++ {
+		v := Elt(r.Intn(20))
+		switch op := r.Intn(10); {
+		case op < 4:
+			c.Add(v)
+			model[v]++
+			size++
+		case op < 7:
+			want := model[v] > 0
+			if got := c.Remove(v); got != want {
+				t.Fatalf("op %d: Remove(%d) = %v, model says %v", i, v, got, want)
+			}
+			if want {
+				model[v]--
+				size--
+			}
+		case op < 9:
+			if got, want := c.Contains(v), model[v] > 0; got != want {
+				t.Fatalf("op %d: Contains(%d) = %v, model says %v", i, v, got, want)
+			}
+		default:
+			if r.Intn(10) == 0 {
+				c.Clear()
+				model = map[Elt]int{}
+				size = 0
+			}
+		}
+
+		if c.Len() != size {
+			t.Fatalf("op %d: Len = %d, model says %d", i, c.Len(), size)
+		}
+	}
+}
+
+// testContainerConcurrent adds and removes distinct values from many
+// goroutines and checks that nothing is lost or duplicated.
+func testContainerConcurrent(t *testing.T, cfg *config, c adts.Container) {
+	per := 100
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < per; i++ {
+				c.Add(Elt(w*per + i))
+				c.Contains(Elt(w*per + i))
+				c.Len()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if c.Len() != cfg.workers*per {
+		t.Fatalf("Len after concurrent adds = %d, expected %d", c.Len(), cfg.workers*per)
+	}
+
+	for w := 0; w < cfg.workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < per; i++ {
+				if !c.Remove(Elt(w*per + i)) {
+					t.Errorf("Remove(%d) = false after a concurrent add", w*per+i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if !c.IsEmpty() {
+		t.Fatalf("Container should be empty after concurrent removes. Len = %d", c.Len())
+	}
+}
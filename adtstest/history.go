@@ -0,0 +1,147 @@
+package adtstest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// Call is a single method invocation on a container.
+type Call struct {
+	Method string
+	Arg    adts.ContainerElement
+	Idx    int
+}
+
+// String returns a readable form of the call, e.g. Set(3, 7).
+func (c Call) String() string {
+	switch {
+	case c.Method == "Get":
+		return fmt.Sprintf("Get(%d)", c.Idx)
+	case c.Method == "Set":
+		return fmt.Sprintf("Set(%d, %v)", c.Idx, c.Arg)
+	case c.Arg != nil:
+		return fmt.Sprintf("%s(%v)", c.Method, c.Arg)
+	}
+
+	return c.Method + "()"
+}
+
+// Result is what a Call returned. Only the fields that make sense for the
+// method are set: Elt for methods that return an element, Ok for methods that
+// return a bool and N for Len. Panicked is set if the call panicked.
+type Result struct {
+	Elt      adts.ContainerElement
+	Ok       bool
+	N        int
+	Panicked bool
+}
+
+// Operation is a call together with its result, the client that made it and
+// the logical times at which it was invoked and returned.
+type Operation struct {
+	Client int
+	Call   Call
+	Result Result
+	Start  int64
+	End    int64
+}
+
+// String returns a readable form of the operation.
+func (o Operation) String() string {
+	return fmt.Sprintf("client %d [%d,%d]: %v -> %+v", o.Client, o.Start, o.End, o.Call, o.Result)
+}
+
+// History is the list of operations recorded against a container.
+type History []Operation
+
+// Recorder records the operations made on a container from many goroutines.
+// Invocations and responses are stamped from a single atomic clock, so an
+// operation that returned before another was invoked always has a smaller
+// End than the other's Start.
+type Recorder struct {
+	clock atomic.Int64
+	lock  *sync.Mutex
+	ops   History
+}
+
+// MakeRecorder creates a new Recorder with an empty history.
+func MakeRecorder() *Recorder {
+	return &Recorder{lock: &sync.Mutex{}}
+}
+
+// Record invokes fn on behalf of the given client and records the call and
+// its result. A panic in fn is recorded as a Result with Panicked set.
+func (r *Recorder) Record(client int, call Call, fn func() Result) (res Result) {
+	start := r.clock.Add(1)
+	defer func() {
+		if recover() != nil {
+			res = Result{Panicked: true}
+		}
+		end := r.clock.Add(1)
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.ops = append(r.ops, Operation{client, call, res, start, end})
+	}()
+
+	return fn()
+}
+
+// History returns a copy of the recorded operations, in invocation order.
+func (r *Recorder) History() History {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	h := append(History{}, r.ops...)
+	sort.Slice(h, func(i, j int) bool { return h[i].Start < h[j].Start })
+	return h
+}
+
+// recordContainer records the adts.Container methods. It's embedded by the
+// stack, queue and list clients.
+type recordContainer struct {
+	rec    *Recorder
+	client int
+	inner  adts.Container
+}
+
+func (rc recordContainer) Len() int {
+	return rc.rec.Record(rc.client, Call{Method: "Len"}, func() Result {
+		return Result{N: rc.inner.Len()}
+	}).N
+}
+
+func (rc recordContainer) IsEmpty() bool {
+	return rc.rec.Record(rc.client, Call{Method: "IsEmpty"}, func() Result {
+		return Result{Ok: rc.inner.IsEmpty()}
+	}).Ok
+}
+
+func (rc recordContainer) Clear() {
+	rc.rec.Record(rc.client, Call{Method: "Clear"}, func() Result {
+		rc.inner.Clear()
+		return Result{}
+	})
+}
+
+func (rc recordContainer) Contains(item adts.ContainerElement) bool {
+	return rc.rec.Record(rc.client, Call{Method: "Contains", Arg: item}, func() Result {
+		return Result{Ok: rc.inner.Contains(item)}
+	}).Ok
+}
+
+func (rc recordContainer) Add(item adts.ContainerElement) bool {
+	return rc.rec.Record(rc.client, Call{Method: "Add", Arg: item}, func() Result {
+		return Result{Ok: rc.inner.Add(item)}
+	}).Ok
+}
+
+func (rc recordContainer) Remove(item adts.ContainerElement) bool {
+	return rc.rec.Record(rc.client, Call{Method: "Remove", Arg: item}, func() Result {
+		return Result{Ok: rc.inner.Remove(item)}
+	}).Ok
+}
@@ -0,0 +1,343 @@
+package adtstest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	listadts "github.com/johnsrd7/go-adts/lists"
+	queueadts "github.com/johnsrd7/go-adts/queues"
+	stackadts "github.com/johnsrd7/go-adts/stacks"
+)
+
+// Model is a sequential specification of a container. The state is the
+// container's elements in order; Step must not modify the state it's given.
+type Model struct {
+	Name string
+	Step func(state []adts.ContainerElement, call Call) ([]adts.ContainerElement, Result)
+}
+
+// StackModel is the sequential specification of stackadts.Stack. The top of
+// the stack is the end of the state.
+var StackModel = Model{"stack", func(state []adts.ContainerElement, call Call) ([]adts.ContainerElement, Result) {
+	switch call.Method {
+	case "Push":
+		return appendElt(state, call.Arg), Result{Ok: true}
+	case "Pop":
+		if len(state) == 0 {
+			return state, Result{Elt: adts.EmptyContainerElement{}}
+		}
+		return state[: len(state)-1 : len(state)-1], Result{Elt: state[len(state)-1], Ok: true}
+	}
+
+	return containerStep(state, call)
+}}
+
+// QueueModel is the sequential specification of queueadts.Queue. The front
+// of the queue is the start of the state.
+var QueueModel = Model{"queue", func(state []adts.ContainerElement, call Call) ([]adts.ContainerElement, Result) {
+	switch call.Method {
+	case "Enqueue":
+		return appendElt(state, call.Arg), Result{Ok: true}
+	case "Dequeue":
+		if len(state) == 0 {
+			return state, Result{Elt: adts.EmptyContainerElement{}}
+		}
+		return state[1:], Result{Elt: state[0], Ok: true}
+	}
+
+	return containerStep(state, call)
+}}
+
+// ListModel is the sequential specification of listadts.List.
+var ListModel = Model{"list", func(state []adts.ContainerElement, call Call) ([]adts.ContainerElement, Result) {
+	switch call.Method {
+	case "Get":
+		if call.Idx < 0 || call.Idx >= len(state) {
+			return state, Result{Panicked: true}
+		}
+		return state, Result{Elt: state[call.Idx]}
+	case "Set":
+		if call.Idx < 0 || call.Idx >= len(state) {
+			return state, Result{Panicked: true}
+		}
+		next := append([]adts.ContainerElement{}, state...)
+		next[call.Idx] = call.Arg
+		return next, Result{Elt: state[call.Idx]}
+	}
+
+	return containerStep(state, call)
+}}
+
+// containerStep implements the adts.Container methods shared by every model.
+// Add appends to the end, which is the top of a stack and the back of a queue.
+func containerStep(state []adts.ContainerElement, call Call) ([]adts.ContainerElement, Result) {
+	switch call.Method {
+	case "Len":
+		return state, Result{N: len(state)}
+	case "IsEmpty":
+		return state, Result{Ok: len(state) == 0}
+	case "Clear":
+		return nil, Result{}
+	case "Contains":
+		return state, Result{Ok: indexOfElt(state, call.Arg) >= 0}
+	case "Add":
+		return appendElt(state, call.Arg), Result{Ok: true}
+	case "Remove":
+		idx := indexOfElt(state, call.Arg)
+		if idx < 0 {
+			return state, Result{}
+		}
+		next := append(append([]adts.ContainerElement{}, state[:idx]...), state[idx+1:]...)
+		return next, Result{Ok: true}
+	}
+
+	panic("adtstest: unknown method " + call.Method)
+}
+
+// appendElt returns a new state with elt appended, leaving state untouched.
+func appendElt(state []adts.ContainerElement, elt adts.ContainerElement) []adts.ContainerElement {
+	return append(state[:len(state):len(state)], elt)
+}
+
+// indexOfElt returns the index of the first element equal to elt, or -1.
+func indexOfElt(state []adts.ContainerElement, elt adts.ContainerElement) int {
+	for idx, e := range state {
+		if e.Equals(elt) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// sameResult returns whether an actual result matches the model's result.
+func sameResult(actual, expected Result) bool {
+	if actual.Panicked || expected.Panicked {
+		return actual.Panicked == expected.Panicked
+	}
+	if actual.Ok != expected.Ok || actual.N != expected.N {
+		return false
+	}
+	if actual.Elt == nil || expected.Elt == nil {
+		return actual.Elt == nil && expected.Elt == nil
+	}
+
+	return expected.Elt.Equals(actual.Elt)
+}
+
+// CheckLinearizable returns whether the history is linearizable with respect
+// to the model: whether there's a sequential order of the operations, which
+// respects the real time order of non-overlapping operations, in which every
+// operation returns what the model says it should.
+//
+// It uses the Wing and Gong search with Lowe's memoization of (linearized
+// operations, state) pairs. The search is exponential in the worst case, so
+// histories should be kept to a few hundred operations with little overlap.
+func CheckLinearizable(h History, m Model) bool {
+	ops := append(History{}, h...)
+	// History is sorted by Start, but don't rely on callers building it that way.
+	for i := 1; i < len(ops); i++ {
+		for j := i; j > 0 && ops[j].Start < ops[j-1].Start; j-- {
+			ops[j], ops[j-1] = ops[j-1], ops[j]
+		}
+	}
+
+	done := make([]uint64, (len(ops)+63)/64)
+	seen := map[string]bool{}
+
+	var search func(state []adts.ContainerElement, remaining int) bool
+	search = func(state []adts.ContainerElement, remaining int) bool {
+		if remaining == 0 {
+			return true
+		}
+
+		key := stateKey(done, state)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+
+		// Only operations invoked before every remaining operation has
+		// returned can be linearized next.
+		minEnd := int64(-1)
+		for i, op := range ops {
+			if done[i/64]&(1<<(i%64)) == 0 && (minEnd < 0 || op.End < minEnd) {
+				minEnd = op.End
+			}
+		}
+
+		for i, op := range ops {
+			if op.Start > minEnd {
+				break
+			}
+			if done[i/64]&(1<<(i%64)) != 0 {
+				continue
+			}
+
+			next, expected := m.Step(state, op.Call)
+			if !sameResult(op.Result, expected) {
+				continue
+			}
+
+			done[i/64] |= 1 << (i % 64)
+			if search(next, remaining-1) {
+				return true
+			}
+			done[i/64] &^= 1 << (i % 64)
+		}
+
+		return false
+	}
+
+	return search(nil, len(ops))
+}
+
+// stateKey builds the memoization key for a set of linearized operations and
+// a model state.
+func stateKey(done []uint64, state []adts.ContainerElement) string {
+	var sb strings.Builder
+	for _, word := range done {
+		fmt.Fprintf(&sb, "%x.", word)
+	}
+	sb.WriteString("|")
+	for _, elt := range state {
+		fmt.Fprintf(&sb, "%v,", elt)
+	}
+
+	return sb.String()
+}
+
+// runClients runs fn concurrently for every client and waits for them.
+func runClients(clients int, fn func(client int)) {
+	var wg sync.WaitGroup
+	for c := 0; c < clients; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			fn(c)
+		}(c)
+	}
+	wg.Wait()
+}
+
+// linearizabilityRounds, linearizabilityClients and linearizabilityOps bound
+// the size of each checked history to keep the search fast.
+const (
+	linearizabilityRounds  = 50
+	linearizabilityClients = 4
+	linearizabilityOps     = 15
+)
+
+// RunStackLinearizability runs random concurrent workloads against stacks
+// created by makeStack, which must be threadsafe, and checks that every
+// recorded history is linearizable against StackModel.
+func RunStackLinearizability(t *testing.T, makeStack func() stackadts.Stack, opts ...Option) {
+	r := makeConfig(opts).rng()
+
+	for round := 0; round < linearizabilityRounds; round++ {
+		rs := RecordStack(makeStack())
+		seeds := clientSeeds(r)
+		runClients(linearizabilityClients, func(c int) {
+			cr := seededRand(seeds[c])
+			s := rs.Client(c)
+			for i := 0; i < linearizabilityOps; i++ {
+				v := Elt(c*linearizabilityOps + i)
+				switch cr.Intn(6) {
+				case 0, 1:
+					s.Push(v)
+				case 2, 3:
+					s.Pop()
+				case 4:
+					s.Len()
+				default:
+					s.Remove(Elt(cr.Intn(linearizabilityClients * linearizabilityOps)))
+				}
+			}
+		})
+
+		if h := rs.History(); !CheckLinearizable(h, StackModel) {
+			t.Fatalf("Round %d: history is not linearizable:\n%v", round, historyString(h))
+		}
+	}
+}
+
+// RunQueueLinearizability runs random concurrent workloads against queues
+// created by makeQueue, which must be threadsafe, and checks that every
+// recorded history is linearizable against QueueModel.
+func RunQueueLinearizability(t *testing.T, makeQueue func() queueadts.Queue, opts ...Option) {
+	r := makeConfig(opts).rng()
+
+	for round := 0; round < linearizabilityRounds; round++ {
+		rq := RecordQueue(makeQueue())
+		seeds := clientSeeds(r)
+		runClients(linearizabilityClients, func(c int) {
+			cr := seededRand(seeds[c])
+			q := rq.Client(c)
+			for i := 0; i < linearizabilityOps; i++ {
+				v := Elt(c*linearizabilityOps + i)
+				switch cr.Intn(6) {
+				case 0, 1:
+					q.Enqueue(v)
+				case 2, 3:
+					q.Dequeue()
+				case 4:
+					q.Len()
+				default:
+					q.Contains(Elt(cr.Intn(linearizabilityClients * linearizabilityOps)))
+				}
+			}
+		})
+
+		if h := rq.History(); !CheckLinearizable(h, QueueModel) {
+			t.Fatalf("Round %d: history is not linearizable:\n%v", round, historyString(h))
+		}
+	}
+}
+
+// RunListLinearizability runs random concurrent workloads against lists
+// created by makeList, which must be threadsafe, and checks that every
+// recorded history is linearizable against ListModel.
+func RunListLinearizability(t *testing.T, makeList func() listadts.List, opts ...Option) {
+	r := makeConfig(opts).rng()
+
+	for round := 0; round < linearizabilityRounds; round++ {
+		rl := RecordList(makeList())
+		seeds := clientSeeds(r)
+		runClients(linearizabilityClients, func(c int) {
+			cr := seededRand(seeds[c])
+			l := rl.Client(c)
+			for i := 0; i < linearizabilityOps; i++ {
+				v := Elt(c*linearizabilityOps + i)
+				switch cr.Intn(6) {
+				case 0, 1:
+					l.Add(v)
+				case 2:
+					l.Get(cr.Intn(4))
+				case 3:
+					l.Set(cr.Intn(4), v)
+				case 4:
+					l.Len()
+				default:
+					l.Remove(Elt(cr.Intn(linearizabilityClients * linearizabilityOps)))
+				}
+			}
+		})
+
+		if h := rl.History(); !CheckLinearizable(h, ListModel) {
+			t.Fatalf("Round %d: history is not linearizable:\n%v", round, historyString(h))
+		}
+	}
+}
+
+// historyString formats a history one operation per line.
+func historyString(h History) string {
+	lines := make([]string, len(h))
+	for i, op := range h {
+		lines[i] = op.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
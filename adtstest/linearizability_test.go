@@ -0,0 +1,59 @@
+package adtstest
+
+import (
+	"testing"
+)
+
+func TestCheckLinearizable(t *testing.T) {
+	// Two overlapping pushes followed by pops. Either push order is allowed
+	// because they overlap.
+	h := History{
+		{0, Call{Method: "Push", Arg: Elt(1)}, Result{Ok: true}, 1, 4},
+		{1, Call{Method: "Push", Arg: Elt(2)}, Result{Ok: true}, 2, 3},
+		{0, Call{Method: "Pop"}, Result{Elt: Elt(1), Ok: true}, 5, 6},
+		{0, Call{Method: "Pop"}, Result{Elt: Elt(2), Ok: true}, 7, 8},
+	}
+	if !CheckLinearizable(h, StackModel) {
+		t.Error("Overlapping pushes may be linearized in either order.")
+	}
+
+	// Same history, but now the pushes don't overlap, so 2 is on top.
+	h[0].End, h[1].Start = 2, 3
+	if CheckLinearizable(h, StackModel) {
+		t.Error("Sequential pushes must be popped in reverse order.")
+	}
+
+	// A queue must be FIFO.
+	q := History{
+		{0, Call{Method: "Enqueue", Arg: Elt(1)}, Result{Ok: true}, 1, 2},
+		{0, Call{Method: "Enqueue", Arg: Elt(2)}, Result{Ok: true}, 3, 4},
+		{1, Call{Method: "Dequeue"}, Result{Elt: Elt(2), Ok: true}, 5, 6},
+	}
+	if CheckLinearizable(q, QueueModel) {
+		t.Error("Dequeue must return the oldest element.")
+	}
+
+	// A Get past the end of a list panics.
+	l := History{
+		{0, Call{Method: "Get", Idx: 0}, Result{Panicked: true}, 1, 2},
+		{0, Call{Method: "Add", Arg: Elt(1)}, Result{Ok: true}, 3, 4},
+		{0, Call{Method: "Get", Idx: 0}, Result{Elt: Elt(1)}, 5, 6},
+	}
+	if !CheckLinearizable(l, ListModel) {
+		t.Error("Get past the end of the list should panic.")
+	}
+}
+
+func TestCheckLinearizableDetectsRace(t *testing.T) {
+	// Build the racy interleaving by hand: both pops read the top before
+	// either removes it.
+	h := History{
+		{0, Call{Method: "Push", Arg: Elt(1)}, Result{Ok: true}, 1, 2},
+		{0, Call{Method: "Push", Arg: Elt(2)}, Result{Ok: true}, 3, 4},
+		{0, Call{Method: "Pop"}, Result{Elt: Elt(2), Ok: true}, 5, 8},
+		{1, Call{Method: "Pop"}, Result{Elt: Elt(2), Ok: true}, 6, 7},
+	}
+	if CheckLinearizable(h, StackModel) {
+		t.Error("Two pops returning the same element is not linearizable.")
+	}
+}
@@ -0,0 +1,197 @@
+package adtstest
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	listadts "github.com/johnsrd7/go-adts/lists"
+)
+
+// RunListSuite runs the listadts.List contract, including the
+// adts.Container contract, against lists created by makeList. Lists keep
+// their elements in the order they were added, so they're checked against a
+// slice model.
+func RunListSuite(t *testing.T, makeList func() listadts.List, opts ...Option) {
+	cfg := makeConfig(opts)
+
+	t.Run("Container", func(t *testing.T) {
+		RunContainerSuite(t, func() adts.Container { return makeList() }, opts...)
+	})
+	t.Run("Order", func(t *testing.T) {
+		l := makeList()
+		for i := 0; i < 100; i++ {
+			l.Add(Elt(i))
+		}
+		checkList(t, l, seq(100))
+	})
+	t.Run("GetSet", func(t *testing.T) {
+		l := makeList()
+		for i := 0; i < 100; i++ {
+			l.Add(Elt(i))
+		}
+		for i := 0; i < 100; i++ {
+			if old := l.Set(i, Elt(i*2)); !old.Equals(Elt(i)) {
+				t.Fatalf("Set(%d) returned %v, expected the old value %d", i, old, i)
+			}
+		}
+		for i := 0; i < 100; i++ {
+			if !l.Get(i).Equals(Elt(i * 2)) {
+				t.Fatalf("Get(%d) = %v after Set, expected %d", i, l.Get(i), i*2)
+			}
+		}
+	})
+	t.Run("GetOutOfRange", func(t *testing.T) {
+		l := makeList()
+		l.Add(Elt(0))
+		for _, idx := range []int{-1, 1} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Get(%d) on a list of length 1 should panic", idx)
+					}
+				}()
+				l.Get(idx)
+			}()
+		}
+	})
+	t.Run("RemoveFirstOccurrence", func(t *testing.T) {
+		l := makeList()
+		for _, v := range []int{1, 2, 1, 3, 1} {
+			l.Add(Elt(v))
+		}
+		l.Remove(Elt(1))
+		checkList(t, l, []Elt{2, 1, 3, 1})
+		l.Remove(Elt(1))
+		checkList(t, l, []Elt{2, 3, 1})
+		l.Remove(Elt(1))
+		checkList(t, l, []Elt{2, 3})
+		// Removing the last element must leave the list appendable.
+		l.Remove(Elt(3))
+		l.Add(Elt(4))
+		checkList(t, l, []Elt{2, 4})
+	})
+	t.Run("Model", func(t *testing.T) {
+		testListModel(t, cfg, makeList())
+	})
+	if cfg.threadSafe {
+		t.Run("ConcurrentOrder", func(t *testing.T) {
+			testListConcurrent(t, cfg, makeList())
+		})
+	}
+}
+
+// seq returns the elements 0 through n-1.
+func seq(n int) []Elt {
+	elts := make([]Elt, n)
+	for i := range elts {
+		elts[i] = Elt(i)
+	}
+
+	return elts
+}
+
+// checkList checks that the list holds exactly the expected elements, in order.
+func checkList(t *testing.T, l listadts.List, expected []Elt) {
+	t.Helper()
+
+	if l.Len() != len(expected) {
+		t.Fatalf("Len = %d, expected %d (%v)", l.Len(), len(expected), expected)
+	}
+	for idx, v := range expected {
+		if got := l.Get(idx); !got.Equals(v) {
+			t.Fatalf("Get(%d) = %v, expected %v (%v)", idx, got, v, expected)
+		}
+	}
+}
+
+// testListModel runs random operations against the list and a slice model
+// and checks that they always agree.
+func testListModel(t *testing.T, cfg *config, l listadts.List) {
+	r := cfg.rng()
+	model := []Elt{}
+
+	for i := 0; i < cfg.ops; i++ {
+		v := Elt(r.Intn(20))
+		switch op := r.Intn(10); {
+		case op < 4:
+			l.Add(v)
+			model = append(model, v)
+		case op < 6:
+			idx := indexOf(model, v)
+			if got := l.Remove(v); got != (idx >= 0) {
+				t.Fatalf("op %d: Remove(%d) = %v, model says %v", i, v, got, idx >= 0)
+			}
+			if idx >= 0 {
+				model = append(model[:idx], model[idx+1:]...)
+			}
+		case op < 8:
+			if len(model) > 0 {
+				idx := r.Intn(len(model))
+				if old := l.Set(idx, v); !old.Equals(model[idx]) {
+					t.Fatalf("op %d: Set(%d) returned %v, model says %v", i, idx, old, model[idx])
+				}
+				model[idx] = v
+			}
+		case op < 9:
+			if got, want := l.Contains(v), indexOf(model, v) >= 0; got != want {
+				t.Fatalf("op %d: Contains(%d) = %v, model says %v", i, v, got, want)
+			}
+		default:
+			if r.Intn(10) == 0 {
+				l.Clear()
+				model = model[:0]
+			}
+		}
+
+		checkList(t, l, model)
+	}
+}
+
+// indexOf returns the index of the first occurrence of v, or -1.
+func indexOf(elts []Elt, v Elt) int {
+	for idx, e := range elts {
+		if e == v {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// testListConcurrent adds distinct values from many goroutines and checks
+// that every value ends up in the list exactly once, and that each
+// goroutine's values kept their relative order.
+func testListConcurrent(t *testing.T, cfg *config, l listadts.List) {
+	per := 100
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < per; i++ {
+				l.Add(Elt(w*per + i))
+				l.Get(0)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if l.Len() != cfg.workers*per {
+		t.Fatalf("Len after concurrent adds = %d, expected %d", l.Len(), cfg.workers*per)
+	}
+
+	last := make([]int, cfg.workers)
+	for w := range last {
+		last[w] = -1
+	}
+	for idx := 0; idx < l.Len(); idx++ {
+		v := int(l.Get(idx).(Elt))
+		w, i := v/per, v%per
+		if i <= last[w] {
+			t.Fatalf("Values added by one goroutine are out of order at index %d", idx)
+		}
+		last[w] = i
+	}
+}
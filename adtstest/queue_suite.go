@@ -0,0 +1,98 @@
+package adtstest
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	queueadts "github.com/johnsrd7/go-adts/queues"
+)
+
+// RunQueueSuite runs the queueadts.Queue contract, including the
+// adts.Container contract, against queues created by makeQueue.
+func RunQueueSuite(t *testing.T, makeQueue func() queueadts.Queue, opts ...Option) {
+	cfg := makeConfig(opts)
+
+	t.Run("Container", func(t *testing.T) {
+		RunContainerSuite(t, func() adts.Container { return makeQueue() }, opts...)
+	})
+	t.Run("DequeueEmpty", func(t *testing.T) {
+		q := makeQueue()
+		if elt, ok := q.Dequeue(); ok || !elt.Equals(adts.EmptyContainerElement{}) {
+			t.Fatalf("Dequeue on an empty queue = (%v, %v), expected (EmptyContainerElement, false)", elt, ok)
+		}
+	})
+	t.Run("FIFO", func(t *testing.T) {
+		q := makeQueue()
+		for i := 0; i < 100; i++ {
+			if !q.Enqueue(Elt(i)) {
+				t.Fatalf("Enqueue(%d) returned false", i)
+			}
+		}
+		// Add is the same as Enqueue.
+		q.Add(Elt(100))
+		for i := 0; i <= 100; i++ {
+			if elt, ok := q.Dequeue(); !ok || !elt.Equals(Elt(i)) {
+				t.Fatalf("Dequeue = (%v, %v), expected (%d, true)", elt, ok, i)
+			}
+		}
+		if !q.IsEmpty() {
+			t.Fatal("Queue should be empty after dequeuing every element")
+		}
+	})
+	t.Run("Model", func(t *testing.T) {
+		testQueueModel(t, cfg, makeQueue())
+	})
+	if cfg.threadSafe {
+		t.Run("ConcurrentEnqueueDequeue", func(t *testing.T) {
+			testTakeConcurrent(t, cfg, makeQueue(), func(c adts.Container) (adts.ContainerElement, bool) {
+				return c.(queueadts.Queue).Dequeue()
+			})
+		})
+	}
+}
+
+// testQueueModel runs random operations against the queue and a slice model
+// (front of the queue first) and checks that they always agree. Values are
+// never repeated so that Remove is unambiguous.
+func testQueueModel(t *testing.T, cfg *config, q queueadts.Queue) {
+	r := cfg.rng()
+	model := []Elt{}
+	next := 0
+
+	for i := 0; i < cfg.ops; i++ {
+		switch op := r.Intn(10); {
+		case op < 5:
+			q.Enqueue(Elt(next))
+			model = append(model, Elt(next))
+			next++
+		case op < 8:
+			elt, ok := q.Dequeue()
+			if ok != (len(model) > 0) {
+				t.Fatalf("op %d: Dequeue ok = %v, model has %d elements", i, ok, len(model))
+			}
+			if ok {
+				if !elt.Equals(model[0]) {
+					t.Fatalf("op %d: Dequeue = %v, model says %v", i, elt, model[0])
+				}
+				model = model[1:]
+			}
+		case op < 9:
+			if len(model) > 0 {
+				idx := r.Intn(len(model))
+				if !q.Remove(model[idx]) {
+					t.Fatalf("op %d: Remove(%v) = false", i, model[idx])
+				}
+				model = append(model[:idx:idx], model[idx+1:]...)
+			}
+		default:
+			if r.Intn(10) == 0 {
+				q.Clear()
+				model = nil
+			}
+		}
+
+		if q.Len() != len(model) {
+			t.Fatalf("op %d: Len = %d, model says %d", i, q.Len(), len(model))
+		}
+	}
+}
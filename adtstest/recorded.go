@@ -0,0 +1,119 @@
+package adtstest
+
+import (
+	adts "github.com/johnsrd7/go-adts"
+	listadts "github.com/johnsrd7/go-adts/lists"
+	queueadts "github.com/johnsrd7/go-adts/queues"
+	stackadts "github.com/johnsrd7/go-adts/stacks"
+)
+
+// RecordedStack wraps a threadsafe stack and records every operation made
+// through its clients.
+type RecordedStack struct {
+	inner stackadts.Stack
+	*Recorder
+}
+
+// RecordStack wraps the given stack so that its operations can be recorded.
+func RecordStack(s stackadts.Stack) *RecordedStack {
+	return &RecordedStack{s, MakeRecorder()}
+}
+
+// Client returns a view of the stack whose operations are recorded as made by
+// the given client. Each goroutine should use its own client.
+func (rs *RecordedStack) Client(id int) stackadts.Stack {
+	return stackClient{recordContainer{rs.Recorder, id, rs.inner}, rs.inner}
+}
+
+type stackClient struct {
+	recordContainer
+	stack stackadts.Stack
+}
+
+func (sc stackClient) Push(item adts.ContainerElement) bool {
+	return sc.rec.Record(sc.client, Call{Method: "Push", Arg: item}, func() Result {
+		return Result{Ok: sc.stack.Push(item)}
+	}).Ok
+}
+
+func (sc stackClient) Pop() (adts.ContainerElement, bool) {
+	res := sc.rec.Record(sc.client, Call{Method: "Pop"}, func() Result {
+		elt, ok := sc.stack.Pop()
+		return Result{Elt: elt, Ok: ok}
+	})
+	return res.Elt, res.Ok
+}
+
+// RecordedQueue wraps a threadsafe queue and records every operation made
+// through its clients.
+type RecordedQueue struct {
+	inner queueadts.Queue
+	*Recorder
+}
+
+// RecordQueue wraps the given queue so that its operations can be recorded.
+func RecordQueue(q queueadts.Queue) *RecordedQueue {
+	return &RecordedQueue{q, MakeRecorder()}
+}
+
+// Client returns a view of the queue whose operations are recorded as made by
+// the given client. Each goroutine should use its own client.
+func (rq *RecordedQueue) Client(id int) queueadts.Queue {
+	return queueClient{recordContainer{rq.Recorder, id, rq.inner}, rq.inner}
+}
+
+type queueClient struct {
+	recordContainer
+	queue queueadts.Queue
+}
+
+func (qc queueClient) Enqueue(item adts.ContainerElement) bool {
+	return qc.rec.Record(qc.client, Call{Method: "Enqueue", Arg: item}, func() Result {
+		return Result{Ok: qc.queue.Enqueue(item)}
+	}).Ok
+}
+
+func (qc queueClient) Dequeue() (adts.ContainerElement, bool) {
+	res := qc.rec.Record(qc.client, Call{Method: "Dequeue"}, func() Result {
+		elt, ok := qc.queue.Dequeue()
+		return Result{Elt: elt, Ok: ok}
+	})
+	return res.Elt, res.Ok
+}
+
+// RecordedList wraps a threadsafe list and records every operation made
+// through its clients.
+type RecordedList struct {
+	inner listadts.List
+	*Recorder
+}
+
+// RecordList wraps the given list so that its operations can be recorded.
+func RecordList(l listadts.List) *RecordedList {
+	return &RecordedList{l, MakeRecorder()}
+}
+
+// Client returns a view of the list whose operations are recorded as made by
+// the given client. Each goroutine should use its own client. Get and Set
+// calls that panic (e.g. because the index is out of range) are recorded and
+// then swallowed, returning nil.
+func (rl *RecordedList) Client(id int) listadts.List {
+	return listClient{recordContainer{rl.Recorder, id, rl.inner}, rl.inner}
+}
+
+type listClient struct {
+	recordContainer
+	list listadts.List
+}
+
+func (lc listClient) Get(idx int) adts.ContainerElement {
+	return lc.rec.Record(lc.client, Call{Method: "Get", Idx: idx}, func() Result {
+		return Result{Elt: lc.list.Get(idx)}
+	}).Elt
+}
+
+func (lc listClient) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	return lc.rec.Record(lc.client, Call{Method: "Set", Arg: newVal, Idx: idx}, func() Result {
+		return Result{Elt: lc.list.Set(idx, newVal)}
+	}).Elt
+}
@@ -0,0 +1,159 @@
+package adtstest
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	stackadts "github.com/johnsrd7/go-adts/stacks"
+)
+
+// RunStackSuite runs the stackadts.Stack contract, including the
+// adts.Container contract, against stacks created by makeStack.
+func RunStackSuite(t *testing.T, makeStack func() stackadts.Stack, opts ...Option) {
+	cfg := makeConfig(opts)
+
+	t.Run("Container", func(t *testing.T) {
+		RunContainerSuite(t, func() adts.Container { return makeStack() }, opts...)
+	})
+	t.Run("PopEmpty", func(t *testing.T) {
+		s := makeStack()
+		if elt, ok := s.Pop(); ok || !elt.Equals(adts.EmptyContainerElement{}) {
+			t.Fatalf("Pop on an empty stack = (%v, %v), expected (EmptyContainerElement, false)", elt, ok)
+		}
+	})
+	t.Run("LIFO", func(t *testing.T) {
+		s := makeStack()
+		for i := 0; i < 100; i++ {
+			if !s.Push(Elt(i)) {
+				t.Fatalf("Push(%d) returned false", i)
+			}
+		}
+		// Add is the same as Push.
+		s.Add(Elt(100))
+		for i := 100; i >= 0; i-- {
+			if elt, ok := s.Pop(); !ok || !elt.Equals(Elt(i)) {
+				t.Fatalf("Pop = (%v, %v), expected (%d, true)", elt, ok, i)
+			}
+		}
+		if !s.IsEmpty() {
+			t.Fatal("Stack should be empty after popping every element")
+		}
+	})
+	t.Run("Model", func(t *testing.T) {
+		testStackModel(t, cfg, makeStack())
+	})
+	if cfg.threadSafe {
+		t.Run("ConcurrentPushPop", func(t *testing.T) {
+			testTakeConcurrent(t, cfg, makeStack(), func(c adts.Container) (adts.ContainerElement, bool) {
+				return c.(stackadts.Stack).Pop()
+			})
+		})
+	}
+}
+
+// testStackModel runs random operations against the stack and a slice model
+// (top of the stack at the end) and checks that they always agree. Values
+// are never repeated so that Remove is unambiguous.
+func testStackModel(t *testing.T, cfg *config, s stackadts.Stack) {
+	r := cfg.rng()
+	model := []Elt{}
+	next := 0
+
+	for i := 0; i < cfg.ops; i++ {
+		switch op := r.Intn(10); {
+		case op < 5:
+			s.Push(Elt(next))
+			model = append(model, Elt(next))
+			next++
+		case op < 8:
+			elt, ok := s.Pop()
+			if ok != (len(model) > 0) {
+				t.Fatalf("op %d: Pop ok = %v, model has %d elements", i, ok, len(model))
+			}
+			if ok {
+				if want := model[len(model)-1]; !elt.Equals(want) {
+					t.Fatalf("op %d: Pop = %v, model says %v", i, elt, want)
+				}
+				model = model[:len(model)-1]
+			}
+		case op < 9:
+			if len(model) > 0 {
+				idx := r.Intn(len(model))
+				if !s.Remove(model[idx]) {
+					t.Fatalf("op %d: Remove(%v) = false", i, model[idx])
+				}
+				model = append(model[:idx], model[idx+1:]...)
+			}
+		default:
+			if r.Intn(10) == 0 {
+				s.Clear()
+				model = model[:0]
+			}
+		}
+
+		if s.Len() != len(model) {
+			t.Fatalf("op %d: Len = %d, model says %d", i, s.Len(), len(model))
+		}
+	}
+}
+
+// testTakeConcurrent adds distinct values from producer goroutines while
+// consumer goroutines take them with take, and checks that every value is
+// taken exactly once.
+func testTakeConcurrent(t *testing.T, cfg *config, c adts.Container, take func(adts.Container) (adts.ContainerElement, bool)) {
+	per := 200
+	total := cfg.workers * per
+
+	var producers sync.WaitGroup
+	for w := 0; w < cfg.workers; w++ {
+		producers.Add(1)
+		go func(w int) {
+			defer producers.Done()
+			for i := 0; i < per; i++ {
+				c.Add(Elt(w*per + i))
+			}
+		}(w)
+	}
+
+	seen := make([]int, total)
+	lock := &sync.Mutex{}
+	done := make(chan struct{})
+	go func() {
+		producers.Wait()
+		close(done)
+	}()
+
+	var consumers sync.WaitGroup
+	for w := 0; w < cfg.workers; w++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				elt, ok := take(c)
+				if !ok {
+					select {
+					case <-done:
+						// The producers are done, so one more empty take
+						// means everything has been taken.
+						if elt, ok = take(c); !ok {
+							return
+						}
+					default:
+						continue
+					}
+				}
+				lock.Lock()
+				seen[int(elt.(Elt))]++
+				lock.Unlock()
+			}
+		}()
+	}
+	consumers.Wait()
+
+	for v, count := range seen {
+		if count != 1 {
+			t.Fatalf("Value %d was taken %d times, expected exactly once", v, count)
+		}
+	}
+}
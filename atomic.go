@@ -0,0 +1,17 @@
+package adts
+
+// Predicate reports whether the given element matches some condition.
+type Predicate func(ContainerElement) bool
+
+// Atomic is implemented by containers that can run a batch of operations
+// under a single acquisition of their lock.
+//
+// The tx passed to fn is a non-threadsafe view of the container that shares
+// its state. It's only valid until fn returns and must not be used from other
+// goroutines. Calling methods on the original container from inside fn will
+// deadlock when the container is threadsafe.
+type Atomic interface {
+	Atomically(fn func(tx Container) error) error
+
+	Container
+}
@@ -0,0 +1,149 @@
+package adts
+
+// CapacityPolicy decides how a SliceContainer's backing slice grows and
+// shrinks. A container without a policy grows the way append does and halves
+// its capacity whenever a removal drops its occupancy to ShrinkFactor.
+type CapacityPolicy interface {
+	// Grow returns the capacity to use when need elements don't fit in the
+	// current capacity. Returning less than need refuses the addition, and
+	// Add returns false.
+	Grow(cap, need int) int
+
+	// Shrink returns the capacity to use after a removal leaves len elements
+	// in a slice of the given capacity. Returning cap keeps the slice as is.
+	Shrink(len, cap int) int
+}
+
+// doubled returns the capacity append would usually pick: twice the current
+// capacity, or need if that's larger.
+func doubled(cap, need int) int {
+	if 2*cap > need {
+		return 2 * cap
+	}
+
+	return need
+}
+
+// NeverShrink is a CapacityPolicy that grows like append and never gives
+// memory back. Use TrimToSize to release it explicitly.
+type NeverShrink struct{}
+
+// Grow doubles the capacity.
+func (NeverShrink) Grow(cap, need int) int {
+	return doubled(cap, need)
+}
+
+// Shrink keeps the current capacity.
+func (NeverShrink) Shrink(len, cap int) int {
+	return cap
+}
+
+// Hysteresis is a CapacityPolicy with a gap between when it shrinks and
+// where it shrinks to, so workloads that hover around one size don't keep
+// reallocating. It shrinks once occupancy falls below ShrinkBelow, to the
+// capacity that puts occupancy at Target, and never below MinCap.
+//
+// For example Hysteresis{ShrinkBelow: 0.25, Target: 0.75} shrinks a slice
+// of capacity 100 once it holds fewer than 25 elements, to a capacity that's
+// 75% full, and the container then has to grow by a third before it
+// reallocates again.
+type Hysteresis struct {
+	ShrinkBelow float32
+	Target      float32
+	MinCap      int
+}
+
+// Grow doubles the capacity.
+func (h Hysteresis) Grow(cap, need int) int {
+	return doubled(cap, need)
+}
+
+// Shrink returns the capacity that puts occupancy at Target once occupancy
+// is below ShrinkBelow.
+func (h Hysteresis) Shrink(len, cap int) int {
+	if cap <= h.MinCap || h.Target <= 0 || float32(len) >= h.ShrinkBelow*float32(cap) {
+		return cap
+	}
+
+	newCap := int(float32(len)/h.Target) + 1
+	if newCap < h.MinCap {
+		newCap = h.MinCap
+	}
+	if newCap > cap {
+		return cap
+	}
+
+	return newCap
+}
+
+// FixedStep is a CapacityPolicy that grows and shrinks in multiples of Step
+// elements. It shrinks once more than two steps are unused, leaving one
+// spare step, so memory waste is bounded by 2*Step elements.
+type FixedStep struct {
+	Step int
+}
+
+// step returns the step size, treating anything below 1 as 1.
+func (f FixedStep) step() int {
+	if f.Step < 1 {
+		return 1
+	}
+
+	return f.Step
+}
+
+// roundUp rounds n up to a multiple of the step size.
+func (f FixedStep) roundUp(n int) int {
+	step := f.step()
+	return (n + step - 1) / step * step
+}
+
+// Grow rounds need up to the next step.
+func (f FixedStep) Grow(cap, need int) int {
+	return f.roundUp(need)
+}
+
+// Shrink drops the capacity to one step above len once more than two steps
+// are unused.
+func (f FixedStep) Shrink(len, cap int) int {
+	if cap-len <= 2*f.step() {
+		return cap
+	}
+
+	return f.roundUp(len) + f.step()
+}
+
+// MemoryBudget is a CapacityPolicy that never lets the capacity exceed
+// MaxElements, so Add returns false once the container is full. Within the
+// budget it grows like append and shrinks like the default policy, halving
+// the capacity once occupancy drops to a quarter.
+type MemoryBudget struct {
+	MaxElements int
+}
+
+// Grow doubles the capacity up to the budget, and refuses to grow past it.
+func (m MemoryBudget) Grow(cap, need int) int {
+	if need > m.MaxElements {
+		return cap
+	}
+
+	newCap := doubled(cap, need)
+	if newCap > m.MaxElements {
+		return m.MaxElements
+	}
+
+	return newCap
+}
+
+// Shrink halves the capacity once occupancy drops to a quarter, and trims
+// any capacity above the budget.
+func (m MemoryBudget) Shrink(len, cap int) int {
+	if cap > m.MaxElements && len <= m.MaxElements {
+		cap = m.MaxElements
+	}
+	if cap > 0 && len*4 <= cap {
+		return cap / 2
+	}
+
+	return cap
+}
@@ -0,0 +1,126 @@
+package adts
+
+import "testing"
+
+func TestNeverShrink(t *testing.T) {
+	container := NewSliceContainer(WithCapacityPolicy(NeverShrink{}))
+	for i := 0; i < 100; i++ {
+		container.Add(IntElt(i))
+	}
+	capacity := container.Cap()
+
+	for i := 0; i < 100; i++ {
+		container.Remove(IntElt(i))
+	}
+	if container.Cap() != capacity {
+		t.Errorf("NeverShrink shouldn't shrink. Expected: %d, Actual: %d", capacity, container.Cap())
+	}
+
+	container.Clear()
+	if container.Cap() != capacity {
+		t.Errorf("NeverShrink should keep its capacity on Clear. Expected: %d, Actual: %d", capacity, container.Cap())
+	}
+
+	container.TrimToSize()
+	if container.Cap() != 0 {
+		t.Errorf("TrimToSize should release the capacity. Expected: %d, Actual: %d", 0, container.Cap())
+	}
+}
+
+func TestHysteresis(t *testing.T) {
+	policy := Hysteresis{ShrinkBelow: 0.25, Target: 0.5, MinCap: 8}
+
+	tests := []struct {
+		len, cap, expected int
+	}{
+		{30, 100, 100},
+		{24, 100, 49},
+		{2, 100, 8},
+		{0, 8, 8},
+	}
+
+	for _, test := range tests {
+		if actual := policy.Shrink(test.len, test.cap); actual != test.expected {
+			t.Errorf("Shrink(%d, %d) Expected: %d, Actual: %d", test.len, test.cap, test.expected, actual)
+		}
+	}
+
+	// Oscillating around a size shouldn't reallocate on every cycle.
+	m := MakeMetrics()
+	container := NewSliceContainer(WithCapacityPolicy(policy), WithMetrics(m))
+	for i := 0; i < 64; i++ {
+		container.Add(IntElt(i))
+	}
+	for cycle := 0; cycle < 10; cycle++ {
+		for i := 0; i < 32; i++ {
+			container.Remove(IntElt(i))
+		}
+		for i := 0; i < 32; i++ {
+			container.Add(IntElt(i))
+		}
+	}
+	if shrinks := m.Stats().Shrinks; shrinks != 0 {
+		t.Errorf("Hysteresis shrank while oscillating. Expected: %d, Actual: %d", 0, shrinks)
+	}
+}
+
+func TestFixedStep(t *testing.T) {
+	policy := FixedStep{Step: 10}
+	if actual := policy.Grow(10, 11); actual != 20 {
+		t.Errorf("Grow should round up to the next step. Expected: %d, Actual: %d", 20, actual)
+	}
+	if actual := policy.Shrink(15, 30); actual != 30 {
+		t.Errorf("Shrink should keep up to two unused steps. Expected: %d, Actual: %d", 30, actual)
+	}
+	if actual := policy.Shrink(5, 30); actual != 20 {
+		t.Errorf("Shrink should leave one spare step. Expected: %d, Actual: %d", 20, actual)
+	}
+
+	container := NewSliceContainer(WithCapacityPolicy(policy))
+	for i := 0; i < 25; i++ {
+		container.Add(IntElt(i))
+	}
+	if container.Cap() != 30 {
+		t.Errorf("Wrong capacity. Expected: %d, Actual: %d", 30, container.Cap())
+	}
+}
+
+func TestMemoryBudget(t *testing.T) {
+	container := NewSliceContainer(WithCapacityPolicy(MemoryBudget{MaxElements: 10}))
+	for i := 0; i < 10; i++ {
+		if !container.Add(IntElt(i)) {
+			t.Fatalf("Add within the budget failed at %d.", i)
+		}
+	}
+
+	if container.Add(IntElt(10)) || container.Len() != 10 || container.Cap() != 10 {
+		t.Errorf("Add past the budget should fail. Len: %d, Cap: %d", container.Len(), container.Cap())
+	}
+	if container.Reserve(1) {
+		t.Error("Reserve past the budget should fail.")
+	}
+
+	for i := 0; i < 8; i++ {
+		container.Remove(IntElt(i))
+	}
+	if container.Cap() != 5 {
+		t.Errorf("MemoryBudget should halve at a quarter full. Expected: %d, Actual: %d", 5, container.Cap())
+	}
+}
+
+func TestSliceContainerReserve(t *testing.T) {
+	container := MakeSliceContainer()
+	container.Add(IntElt(0))
+
+	if !container.Reserve(100) || container.Cap() < 101 {
+		t.Errorf("Reserve didn't make room. Expected at least: %d, Actual: %d", 101, container.Cap())
+	}
+
+	capacity := container.Cap()
+	for i := 1; i <= 100; i++ {
+		container.Add(IntElt(i))
+	}
+	if container.Cap() != capacity {
+		t.Errorf("Adding reserved elements shouldn't reallocate. Expected: %d, Actual: %d", capacity, container.Cap())
+	}
+}
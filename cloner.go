@@ -0,0 +1,39 @@
+package adts
+
+// Cloner is implemented by elements that can make a deep copy of themselves.
+// Cloning a container clones its Cloner elements and shares the rest, so
+// elements that hold mutable state (slices, maps, pointers) should implement
+// Cloner if copies of a container need to be fully independent.
+type Cloner interface {
+	Clone() ContainerElement
+	ContainerElement
+}
+
+// CloneElement returns a deep copy of e if it implements Cloner, and e itself
+// otherwise.
+func CloneElement(e ContainerElement) ContainerElement {
+	if c, ok := e.(Cloner); ok {
+		return c.Clone()
+	}
+
+	return e
+}
+
+// CloneElements returns a new slice holding a copy of each element, made
+// with CloneElement.
+func CloneElements(elts []ContainerElement) []ContainerElement {
+	ret := make([]ContainerElement, len(elts))
+	for idx, elt := range elts {
+		ret[idx] = CloneElement(elt)
+	}
+
+	return ret
+}
+
+// Snapshotter is implemented by containers that can copy out their elements.
+// Snapshot returns them in the container's iteration order, and
+// SnapshotIterator iterates over such a copy.
+type Snapshotter interface {
+	Snapshot() []ContainerElement
+	SnapshotIterator() Iterator
+}
@@ -0,0 +1,49 @@
+package adts
+
+import "testing"
+
+func TestCloneElement(t *testing.T) {
+	b := Bytes{1, 2}
+	clone := CloneElement(b).(Bytes)
+	clone[0] = 9
+	if b[0] != 1 {
+		t.Error("Cloning Bytes should copy the slice.")
+	}
+
+	if CloneElement(IntElt(1)) != IntElt(1) {
+		t.Error("Elements that aren't Cloners should be returned as is.")
+	}
+}
+
+func TestSliceContainerClone(t *testing.T) {
+	container := NewSliceContainer(WithThreadSafety(), WithEquality(lastDigit))
+	container.Add(Bytes{1})
+	container.Add(IntElt(2))
+
+	clone := container.Clone()
+	clone.Backer[0].(Bytes)[0] = 9
+	clone.Add(IntElt(3))
+
+	if container.Len() != 2 || !container.Backer[0].Equals(Bytes{1}) {
+		t.Error("Changing the clone changed the original.")
+	}
+	if !clone.ThreadSafe || clone.Lock == container.Lock || !clone.Contains(IntElt(12)) {
+		t.Error("The clone should keep the settings but have its own lock.")
+	}
+
+	snapshot := container.Snapshot()
+	snapshot[1] = IntElt(100)
+	if !container.Backer[1].Equals(IntElt(2)) {
+		t.Error("Changing a snapshot changed the container.")
+	}
+
+	it := container.SnapshotIterator()
+	container.Clear()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("SnapshotIterator saw a later change. Expected: %d, Actual: %d", 2, count)
+	}
+}
@@ -25,3 +25,23 @@ type Container interface {
 	Add(ContainerElement) bool
 	Remove(ContainerElement) bool
 }
+
+// ElementRemover is implemented by containers that can report which element
+// Remove took out. Under a custom EqualFunc that can differ from the element
+// that was asked for.
+type ElementRemover interface {
+	RemoveElement(ContainerElement) (ContainerElement, bool)
+}
+
+// RemoveElement removes the first element of c equal to item and returns it.
+// Containers that don't implement ElementRemover report item itself.
+func RemoveElement(c Container, item ContainerElement) (ContainerElement, bool) {
+	if r, ok := c.(ElementRemover); ok {
+		return r.RemoveElement(item)
+	}
+	if !c.Remove(item) {
+		return EmptyContainerElement{}, false
+	}
+
+	return item, true
+}
@@ -0,0 +1,362 @@
+package adts
+
+import (
+	"bytes"
+	"hash/fnv"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The element types in this file wrap common Go values so they can be stored
+// in containers. Each one is only ever equal to elements of its own type, so
+// Int(1) doesn't equal Int64(1). The ordered types compare elements of other
+// types by their type names, which keeps Compare a total order across mixed
+// containers.
+
+// Int is a ContainerElement wrapper for an int.
+type Int int
+
+// Equals returns true if the given element is an Int with the same value.
+func (i Int) Equals(other ContainerElement) bool {
+	o, ok := other.(Int)
+	return ok && i == o
+}
+
+// Hash returns a hash of the value.
+func (i Int) Hash() uint64 {
+	return mix64(uint64(i))
+}
+
+// Compare orders Ints by value.
+func (i Int) Compare(other ContainerElement) int {
+	if o, ok := other.(Int); ok {
+		return compareInts(int64(i), int64(o))
+	}
+	return compareTypes(i, other)
+}
+
+// Int64 is a ContainerElement wrapper for an int64.
+type Int64 int64
+
+// Equals returns true if the given element is an Int64 with the same value.
+func (i Int64) Equals(other ContainerElement) bool {
+	o, ok := other.(Int64)
+	return ok && i == o
+}
+
+// Hash returns a hash of the value.
+func (i Int64) Hash() uint64 {
+	return mix64(uint64(i))
+}
+
+// Compare orders Int64s by value.
+func (i Int64) Compare(other ContainerElement) int {
+	if o, ok := other.(Int64); ok {
+		return compareInts(int64(i), int64(o))
+	}
+	return compareTypes(i, other)
+}
+
+// Uint64 is a ContainerElement wrapper for a uint64.
+type Uint64 uint64
+
+// Equals returns true if the given element is a Uint64 with the same value.
+func (u Uint64) Equals(other ContainerElement) bool {
+	o, ok := other.(Uint64)
+	return ok && u == o
+}
+
+// Hash returns a hash of the value.
+func (u Uint64) Hash() uint64 {
+	return mix64(uint64(u))
+}
+
+// Compare orders Uint64s by value.
+func (u Uint64) Compare(other ContainerElement) int {
+	o, ok := other.(Uint64)
+	switch {
+	case !ok:
+		return compareTypes(u, other)
+	case u < o:
+		return -1
+	case u > o:
+		return 1
+	}
+	return 0
+}
+
+// Float64 is a ContainerElement wrapper for a float64.
+//
+// Unlike ==, every NaN equals every other NaN so that Equals stays reflexive,
+// and NaN is ordered before every other value (including -Inf). Positive and
+// negative zero are equal and hash the same.
+type Float64 float64
+
+// Equals returns true if the given element is a Float64 with the same value.
+func (f Float64) Equals(other ContainerElement) bool {
+	o, ok := other.(Float64)
+	return ok && f.Compare(o) == 0
+}
+
+// Hash returns a hash of the value.
+func (f Float64) Hash() uint64 {
+	switch {
+	case math.IsNaN(float64(f)):
+		return mix64(math.Float64bits(math.NaN()))
+	case f == 0:
+		return mix64(0)
+	}
+	return mix64(math.Float64bits(float64(f)))
+}
+
+// Compare orders Float64s by value, with NaN first.
+func (f Float64) Compare(other ContainerElement) int {
+	o, ok := other.(Float64)
+	if !ok {
+		return compareTypes(f, other)
+	}
+
+	fNaN, oNaN := math.IsNaN(float64(f)), math.IsNaN(float64(o))
+	switch {
+	case fNaN && oNaN:
+		return 0
+	case fNaN:
+		return -1
+	case oNaN:
+		return 1
+	case f < o:
+		return -1
+	case f > o:
+		return 1
+	}
+	return 0
+}
+
+// String is a ContainerElement wrapper for a string.
+type String string
+
+// Equals returns true if the given element is a String with the same value.
+func (s String) Equals(other ContainerElement) bool {
+	o, ok := other.(String)
+	return ok && s == o
+}
+
+// Hash returns a hash of the value.
+func (s String) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// Compare orders Strings lexically by bytes.
+func (s String) Compare(other ContainerElement) int {
+	if o, ok := other.(String); ok {
+		return strings.Compare(string(s), string(o))
+	}
+	return compareTypes(s, other)
+}
+
+// Bytes is a ContainerElement wrapper for a byte slice. A nil slice equals
+// an empty one.
+type Bytes []byte
+
+// Equals returns true if the given element is a Bytes with the same contents.
+func (b Bytes) Equals(other ContainerElement) bool {
+	o, ok := other.(Bytes)
+	return ok && bytes.Equal(b, o)
+}
+
+// Hash returns a hash of the contents.
+func (b Bytes) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
+
+// Compare orders Bytes lexically.
+func (b Bytes) Compare(other ContainerElement) int {
+	if o, ok := other.(Bytes); ok {
+		return bytes.Compare(b, o)
+	}
+	return compareTypes(b, other)
+}
+
+// Clone returns a copy of the bytes.
+func (b Bytes) Clone() ContainerElement {
+	if b == nil {
+		return Bytes(nil)
+	}
+
+	return append(Bytes{}, b...)
+}
+
+// Bool is a ContainerElement wrapper for a bool.
+type Bool bool
+
+// Equals returns true if the given element is a Bool with the same value.
+func (b Bool) Equals(other ContainerElement) bool {
+	o, ok := other.(Bool)
+	return ok && b == o
+}
+
+// Hash returns a hash of the value.
+func (b Bool) Hash() uint64 {
+	if b {
+		return mix64(1)
+	}
+	return mix64(0)
+}
+
+// Compare orders false before true.
+func (b Bool) Compare(other ContainerElement) int {
+	o, ok := other.(Bool)
+	switch {
+	case !ok:
+		return compareTypes(b, other)
+	case b == o:
+		return 0
+	case !bool(b):
+		return -1
+	}
+	return 1
+}
+
+// Time is a ContainerElement wrapper for a time.Time. Times are equal when
+// they're the same instant, even if their locations differ.
+type Time struct {
+	time.Time
+}
+
+// Equals returns true if the given element is a Time at the same instant.
+func (t Time) Equals(other ContainerElement) bool {
+	o, ok := other.(Time)
+	return ok && t.Time.Equal(o.Time)
+}
+
+// Hash returns a hash of the instant.
+func (t Time) Hash() uint64 {
+	return mix64(uint64(t.Unix())) ^ mix64(uint64(t.Nanosecond()))
+}
+
+// Compare orders Times chronologically.
+func (t Time) Compare(other ContainerElement) int {
+	if o, ok := other.(Time); ok {
+		return t.Time.Compare(o.Time)
+	}
+	return compareTypes(t, other)
+}
+
+// ofSeed is the seed used to hash Of values. Hashes are only stable within
+// one run of a program.
+var ofSeed = maphash.MakeSeed()
+
+// Of is a ContainerElement wrapper for any comparable value. Two Ofs are
+// equal when they wrap the same type and their values are ==. A value that
+// == would panic on (a slice held in an Of[any], say) is compared with
+// reflect.DeepEqual instead, but DeepElement is the better fit for those.
+type Of[T comparable] struct {
+	Value T
+}
+
+// Wrap wraps the given value in an Of.
+func Wrap[T comparable](v T) Of[T] {
+	return Of[T]{v}
+}
+
+// Equals returns true if the given element is an Of[T] with an equal value.
+func (o Of[T]) Equals(other ContainerElement) bool {
+	oo, ok := other.(Of[T])
+	if !ok {
+		return false
+	}
+	if !comparableValue(o.Value) || !comparableValue(oo.Value) {
+		return reflect.DeepEqual(o.Value, oo.Value)
+	}
+
+	return o.Value == oo.Value
+}
+
+// Hash returns a hash of the value. Values that aren't comparable only hash
+// their type, since DeepEqual values always share it.
+func (o Of[T]) Hash() uint64 {
+	if !comparableValue(o.Value) {
+		return maphash.String(ofSeed, reflect.TypeOf(any(o.Value)).String())
+	}
+
+	return maphash.Comparable(ofSeed, o.Value)
+}
+
+// comparableValue reports whether == can compare v without panicking. Only
+// interfaces, and structs and arrays that may hold them, can hide a value
+// that isn't comparable.
+func comparableValue[T comparable](v T) bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Interface, reflect.Struct, reflect.Array:
+		return reflect.ValueOf(&v).Elem().Comparable()
+	}
+
+	return true
+}
+
+// DeepElement is a ContainerElement wrapper for any value, including ones
+// that aren't comparable (slices, maps, structs containing them). Two
+// DeepElements are equal when their values are reflect.DeepEqual.
+type DeepElement struct {
+	Value interface{}
+}
+
+// Equals returns true if the given element is a DeepElement with a deeply
+// equal value.
+func (de DeepElement) Equals(other ContainerElement) bool {
+	o, ok := other.(DeepElement)
+	return ok && reflect.DeepEqual(de.Value, o.Value)
+}
+
+// mix64 scrambles the bits of x (the splitmix64 finalizer) so that
+// sequential values don't produce sequential hashes.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// compareInts returns -1, 0 or 1 depending on whether a is less than, equal
+// to or greater than b.
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareTypes orders elements of different types by their package paths and
+// then their type names. Distinct types can share both (types declared in
+// different functions), so those are ordered by their type descriptors,
+// which is stable for the life of the process.
+func compareTypes(a, b ContainerElement) int {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if tb == nil {
+		return 1
+	}
+	if ta == tb {
+		return 0
+	}
+
+	if cmp := strings.Compare(ta.PkgPath(), tb.PkgPath()); cmp != 0 {
+		return cmp
+	}
+	if cmp := strings.Compare(ta.String(), tb.String()); cmp != 0 {
+		return cmp
+	}
+
+	return compareInts(int64(reflect.ValueOf(ta).Pointer()), int64(reflect.ValueOf(tb).Pointer()))
+}
@@ -0,0 +1,99 @@
+package adts
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestElementsContract(t *testing.T) {
+	// Each generator draws from a small range so there are plenty of equal pairs.
+	gens := map[string]func(r *rand.Rand) ContainerElement{
+		"Int":    func(r *rand.Rand) ContainerElement { return Int(r.Intn(10) - 5) },
+		"Int64":  func(r *rand.Rand) ContainerElement { return Int64(r.Intn(10) - 5) },
+		"Uint64": func(r *rand.Rand) ContainerElement { return Uint64(r.Intn(10)) },
+		"Float64": func(r *rand.Rand) ContainerElement {
+			switch r.Intn(5) {
+			case 0:
+				return Float64(math.NaN())
+			case 1:
+				return Float64(math.Copysign(0, -1))
+			case 2:
+				return Float64(math.Inf(-1))
+			}
+			return Float64(r.Intn(5))
+		},
+		"String": func(r *rand.Rand) ContainerElement { return String([]string{"", "a", "ab", "b"}[r.Intn(4)]) },
+		"Bytes": func(r *rand.Rand) ContainerElement {
+			return Bytes([][]byte{nil, {}, {1}, {1, 2}, {2}}[r.Intn(5)])
+		},
+		"Bool": func(r *rand.Rand) ContainerElement { return Bool(r.Intn(2) == 0) },
+		"Time": func(r *rand.Rand) ContainerElement {
+			loc := []*time.Location{time.UTC, time.FixedZone("X", 3600)}[r.Intn(2)]
+			return Time{time.Unix(int64(r.Intn(5)), 0).In(loc)}
+		},
+		"Of":          func(r *rand.Rand) ContainerElement { return Wrap(r.Intn(5)) },
+		"DeepElement": func(r *rand.Rand) ContainerElement { return DeepElement{[]int{r.Intn(3)}} },
+		"OfAny": func(r *rand.Rand) ContainerElement {
+			return Wrap[any]([]any{r.Intn(3), []int{r.Intn(3)}, map[int]int{r.Intn(3): 0}, struct{ S []int }{}}[r.Intn(4)])
+		},
+		"Mixed": func(r *rand.Rand) ContainerElement {
+			return []ContainerElement{Int(1), Int64(1), String("1"), Bool(true), Float64(1)}[r.Intn(5)]
+		},
+	}
+
+	for name, gen := range gens {
+		if violations := VerifyElements(gen, 20, 99); len(violations) != 0 {
+			t.Errorf("%s violates the element contract: %v", name, violations)
+		}
+	}
+}
+
+func TestCompareTypesSameName(t *testing.T) {
+	// Both types print as "adts.dup" but are distinct.
+	a := func() ContainerElement {
+		type dup struct{ EmptyContainerElement }
+		return dup{}
+	}()
+	b := func() ContainerElement {
+		type dup struct{ EmptyContainerElement }
+		return dup{}
+	}()
+
+	ab, ba := compareTypes(a, b), compareTypes(b, a)
+	if ab == 0 || ab != -ba {
+		t.Errorf("Distinct types should be ordered consistently. Actual: %d and %d", ab, ba)
+	}
+	if compareTypes(a, a) != 0 {
+		t.Error("A type should compare equal to itself.")
+	}
+}
+
+func TestFloat64NaN(t *testing.T) {
+	nan := Float64(math.NaN())
+
+	if !nan.Equals(Float64(math.NaN())) {
+		t.Error("NaN should equal NaN.")
+	}
+	if nan.Compare(Float64(math.Inf(-1))) >= 0 {
+		t.Error("NaN should be ordered before -Inf.")
+	}
+	if !Float64(0).Equals(Float64(math.Copysign(0, -1))) || Float64(0).Hash() != Float64(math.Copysign(0, -1)).Hash() {
+		t.Error("Positive and negative zero should be equal and hash the same.")
+	}
+}
+
+func TestElementsInContainer(t *testing.T) {
+	container := MakeSliceContainer()
+	container.Add(String("a"))
+	container.Add(Wrap("a"))
+	container.Add(Bytes("a"))
+
+	if !container.Contains(Bytes("a")) || !container.Contains(Wrap("a")) {
+		t.Error("Container should find wrapped values.")
+	}
+	if container.Contains(Int(0)) || container.Contains(Wrap(0)) {
+		t.Error("Elements of a different type should not be equal.")
+	}
+}
@@ -0,0 +1,61 @@
+package adts
+
+// EqualFunc reports whether two elements should be treated as equal by a
+// container. Containers built with an EqualFunc use it in place of
+// ContainerElement.Equals, so the same element type can be compared
+// differently in different containers. It must follow the same rules as
+// Equals (reflexive, symmetric and transitive).
+type EqualFunc func(a, b ContainerElement) bool
+
+// Equal reports whether a and b are equal using fn, falling back to
+// a.Equals(b) when fn is nil.
+func (fn EqualFunc) Equal(a, b ContainerElement) bool {
+	if fn == nil {
+		return a.Equals(b)
+	}
+
+	return fn(a, b)
+}
+
+// HashFunc hashes an element for containers that bucket their elements.
+// Elements that are equal under the container's EqualFunc must hash the same.
+type HashFunc func(ContainerElement) uint64
+
+// CompareFunc orders two elements for containers that keep their elements
+// sorted. It returns a negative number when a comes before b, zero when they
+// are equal and a positive number otherwise.
+type CompareFunc func(a, b ContainerElement) int
+
+// EqualBy returns an EqualFunc that treats two elements as equal when the
+// given key function returns the same key for both.
+func EqualBy[K comparable](key func(ContainerElement) K) EqualFunc {
+	return func(a, b ContainerElement) bool {
+		return key(a) == key(b)
+	}
+}
+
+// HashBy returns a HashFunc that hashes elements by the key returned from the
+// given key function. It pairs with EqualBy using the same key function.
+func HashBy[K comparable](key func(ContainerElement) K) HashFunc {
+	return func(e ContainerElement) uint64 {
+		return Wrap(key(e)).Hash()
+	}
+}
+
+// EqualFromCompare returns an EqualFunc that treats two elements as equal
+// when cmp orders them the same.
+func EqualFromCompare(cmp CompareFunc) EqualFunc {
+	return func(a, b ContainerElement) bool {
+		return cmp(a, b) == 0
+	}
+}
+
+// firstEqual returns the first of the given functions, or nil if there are
+// none. Constructors use it to take an optional EqualFunc.
+func firstEqual(eq []EqualFunc) EqualFunc {
+	if len(eq) == 0 {
+		return nil
+	}
+
+	return eq[0]
+}
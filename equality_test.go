@@ -0,0 +1,51 @@
+package adts
+
+import "testing"
+
+// lastDigit compares IntElts by their last digit.
+var lastDigit = EqualBy(func(e ContainerElement) int {
+	i, _ := e.(IntElt)
+	return int(i) % 10
+})
+
+func TestEqualFuncDefault(t *testing.T) {
+	var eq EqualFunc
+	if !eq.Equal(IntElt(1), IntElt(1)) || eq.Equal(IntElt(1), IntElt(11)) {
+		t.Error("A nil EqualFunc should fall back to Equals.")
+	}
+	if !lastDigit.Equal(IntElt(1), IntElt(11)) || lastDigit.Equal(IntElt(1), IntElt(2)) {
+		t.Error("EqualBy should compare elements by key.")
+	}
+
+	cmp := EqualFromCompare(func(a, b ContainerElement) int {
+		return int(a.(IntElt)/10) - int(b.(IntElt)/10)
+	})
+	if !cmp.Equal(IntElt(10), IntElt(19)) || cmp.Equal(IntElt(10), IntElt(20)) {
+		t.Error("EqualFromCompare should treat elements that compare as 0 as equal.")
+	}
+
+	hash := HashBy(func(e ContainerElement) int { return int(e.(IntElt)) % 10 })
+	if hash(IntElt(3)) != hash(IntElt(13)) {
+		t.Error("HashBy should hash elements with the same key the same.")
+	}
+}
+
+func TestSliceContainerEqualFunc(t *testing.T) {
+	for _, container := range []*SliceContainer{MakeSliceContainer(lastDigit), MakeSliceContainerThreadSafe(lastDigit)} {
+		container.Add(IntElt(1))
+		container.Add(IntElt(2))
+
+		if !container.Contains(IntElt(11)) || container.Contains(IntElt(3)) {
+			t.Error("Contains should use the container's EqualFunc.")
+		}
+		if idx := container.IndexOf(IntElt(22)); idx != 1 {
+			t.Errorf("IndexOf should use the container's EqualFunc. Expected: %d, Actual: %d", 1, idx)
+		}
+		if !container.Remove(IntElt(21)) || container.Len() != 1 || container.Contains(IntElt(1)) {
+			t.Error("Remove should use the container's EqualFunc.")
+		}
+		if !container.AddIfAbsent(IntElt(1)) || container.AddIfAbsent(IntElt(31)) {
+			t.Error("AddIfAbsent should use the container's EqualFunc.")
+		}
+	}
+}
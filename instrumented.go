@@ -0,0 +1,72 @@
+package adts
+
+import (
+	"time"
+)
+
+// InstrumentedContainer wraps any Container and records the count and latency of every
+// operation, and the high-water mark of its length, in a Metrics.
+type InstrumentedContainer struct {
+	inner   Container
+	metrics *Metrics
+}
+
+// Instrument wraps the given container so that its operations are recorded in m.
+func Instrument(c Container, m *Metrics) *InstrumentedContainer {
+	return &InstrumentedContainer{c, m}
+}
+
+// Metrics returns the metrics the wrapper records into.
+func (i *InstrumentedContainer) Metrics() *Metrics {
+	return i.metrics
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the container.
+func (i *InstrumentedContainer) Len() int {
+	defer i.metrics.ObserveOp(OpLen, time.Now())
+	return i.inner.Len()
+}
+
+// IsEmpty returns if the container is empty or not.
+func (i *InstrumentedContainer) IsEmpty() bool {
+	defer i.metrics.ObserveOp(OpIsEmpty, time.Now())
+	return i.inner.IsEmpty()
+}
+
+// Clear removes all elements from the container.
+func (i *InstrumentedContainer) Clear() {
+	defer i.metrics.ObserveOp(OpClear, time.Now())
+	i.inner.Clear()
+}
+
+// Contains returns true if the given item is in the container.
+func (i *InstrumentedContainer) Contains(item ContainerElement) bool {
+	defer i.metrics.ObserveOp(OpContains, time.Now())
+	return i.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the container.
+func (i *InstrumentedContainer) Add(item ContainerElement) bool {
+	start := time.Now()
+	added := i.inner.Add(item)
+	i.metrics.ObserveOp(OpAdd, start)
+	i.metrics.ObserveLen(i.inner.Len())
+	return added
+}
+
+// Remove returns true if the given element was removed.
+func (i *InstrumentedContainer) Remove(item ContainerElement) bool {
+	defer i.metrics.ObserveOp(OpRemove, time.Now())
+	return i.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (i *InstrumentedContainer) RemoveElement(item ContainerElement) (ContainerElement, bool) {
+	defer i.metrics.ObserveOp(OpRemove, time.Now())
+	return RemoveElement(i.inner, item)
+}
@@ -0,0 +1,275 @@
+// Package nodelist implements the doubly linked list behind the linked
+// stacks and queues. It's internal so callers can't hand a list a node it
+// doesn't own: its methods trust that every node they're given is linked
+// into that list.
+package nodelist
+
+import (
+	"sync"
+)
+
+// Node is an element of a List. Unlike container/list it stores its element
+// with its own type, so reading it never needs a type assertion.
+type Node[E any] struct {
+	Elt  E
+	next *Node[E]
+	prev *Node[E]
+}
+
+// Next returns the next node, or nil at the back of the list.
+func (n *Node[E]) Next() *Node[E] {
+	return n.next
+}
+
+// Prev returns the previous node, or nil at the front of the list.
+func (n *Node[E]) Prev() *Node[E] {
+	return n.prev
+}
+
+// Recycler keeps the nodes that Lists remove so later pushes can reuse them
+// instead of allocating. It's backed by a sync.Pool, so it's safe to share
+// between any number of lists and goroutines, and idle nodes are released by
+// the garbage collector. A nil *Recycler allocates every node.
+type Recycler[E any] struct {
+	pool sync.Pool
+}
+
+// MakeRecycler creates a new, empty Recycler.
+func MakeRecycler[E any]() *Recycler[E] {
+	return &Recycler[E]{}
+}
+
+// get returns a zeroed node holding elt.
+func (r *Recycler[E]) get(elt E) *Node[E] {
+	if r != nil {
+		if n, ok := r.pool.Get().(*Node[E]); ok {
+			n.Elt = elt
+			return n
+		}
+	}
+
+	return &Node[E]{Elt: elt}
+}
+
+// put zeroes the node, so it doesn't keep its element alive, and keeps it
+// for reuse.
+func (r *Recycler[E]) put(n *Node[E]) {
+	if r == nil {
+		return
+	}
+
+	*n = Node[E]{}
+	r.pool.Put(n)
+}
+
+// List is a doubly linked list of Nodes. It's the backing store of the
+// linked stacks and queues, and takes their nodes from a Recycler if it has
+// one. It isn't threadsafe.
+type List[E any] struct {
+	head     *Node[E]
+	tail     *Node[E]
+	len      int
+	recycler *Recycler[E]
+}
+
+// MakeList creates an empty List that takes its nodes from the given
+// recycler, which may be nil.
+func MakeList[E any](r *Recycler[E]) *List[E] {
+	return &List[E]{nil, nil, 0, r}
+}
+
+// Recycler returns the recycler the list takes its nodes from.
+func (nl *List[E]) Recycler() *Recycler[E] {
+	return nl.recycler
+}
+
+// Init empties the list. The nodes aren't recycled, so this takes O(1) time.
+func (nl *List[E]) Init() {
+	nl.head, nl.tail, nl.len = nil, nil, 0
+}
+
+// Len returns the number of nodes in the list.
+func (nl *List[E]) Len() int {
+	return nl.len
+}
+
+// Front returns the first node, or nil if the list is empty.
+func (nl *List[E]) Front() *Node[E] {
+	return nl.head
+}
+
+// Back returns the last node, or nil if the list is empty.
+func (nl *List[E]) Back() *Node[E] {
+	return nl.tail
+}
+
+// PushFront adds a node holding elt to the front of the list and returns it.
+func (nl *List[E]) PushFront(elt E) *Node[E] {
+	n := nl.recycler.get(elt)
+	nl.linkFront(n)
+	nl.len++
+	return n
+}
+
+// PushBack adds a node holding elt to the back of the list and returns it.
+func (nl *List[E]) PushBack(elt E) *Node[E] {
+	n := nl.recycler.get(elt)
+	nl.linkBack(n)
+	nl.len++
+	return n
+}
+
+// Remove unlinks the given node, which must be in the list, recycles it and
+// returns its element. The node must not be used afterwards.
+func (nl *List[E]) Remove(n *Node[E]) E {
+	elt := n.Elt
+	nl.unlink(n)
+	nl.len--
+	nl.recycler.put(n)
+	return elt
+}
+
+// MoveToFront moves the given node, which must be in the list, to the front.
+func (nl *List[E]) MoveToFront(n *Node[E]) {
+	if n == nl.head {
+		return
+	}
+
+	nl.unlink(n)
+	nl.linkFront(n)
+}
+
+// MoveToBack moves the given node, which must be in the list, to the back.
+func (nl *List[E]) MoveToBack(n *Node[E]) {
+	if n == nl.tail {
+		return
+	}
+
+	nl.unlink(n)
+	nl.linkBack(n)
+}
+
+// Append moves every node of other to the back of the list in O(1) time and
+// leaves other empty.
+func (nl *List[E]) Append(other *List[E]) {
+	if other == nl || other.head == nil {
+		return
+	}
+
+	if nl.tail == nil {
+		nl.head = other.head
+	} else {
+		nl.tail.next = other.head
+		other.head.prev = nl.tail
+	}
+	nl.tail = other.tail
+	nl.len += other.len
+
+	other.Init()
+}
+
+// SplitAt cuts the list before the node at idx and returns the nodes from
+// idx on as a new list with the same recycler. It walks from whichever end
+// is closer to idx.
+func (nl *List[E]) SplitAt(idx int) *List[E] {
+	if idx < 0 || idx > nl.len {
+		panic("index out of range")
+	}
+
+	rest := MakeList(nl.recycler)
+	if idx == nl.len {
+		return rest
+	}
+
+	first := nl.nodeAt(idx)
+	rest.head, rest.tail, rest.len = first, nl.tail, nl.len-idx
+	nl.tail = first.prev
+	if nl.tail == nil {
+		nl.head = nil
+	} else {
+		nl.tail.next = nil
+	}
+	first.prev = nil
+	nl.len = idx
+
+	return rest
+}
+
+// Reverse reverses the order of the list in place.
+func (nl *List[E]) Reverse() {
+	for n := nl.head; n != nil; n = n.prev {
+		n.next, n.prev = n.prev, n.next
+	}
+
+	nl.head, nl.tail = nl.tail, nl.head
+}
+
+// Rotate moves the first n nodes to the back of the list, keeping their
+// order. n must be between 0 and Len.
+func (nl *List[E]) Rotate(n int) {
+	if n <= 0 || n >= nl.len {
+		return
+	}
+
+	newHead := nl.nodeAt(n)
+	nl.tail.next = nl.head
+	nl.head.prev = nl.tail
+	nl.head, nl.tail = newHead, newHead.prev
+	nl.head.prev = nil
+	nl.tail.next = nil
+}
+
+// nodeAt returns the node at the given index, which must be in range,
+// walking from the closer end.
+func (nl *List[E]) nodeAt(idx int) *Node[E] {
+	if idx < nl.len/2 {
+		n := nl.head
+		for i := 0; i < idx; i++ {
+			n = n.next
+		}
+		return n
+	}
+
+	n := nl.tail
+	for i := nl.len - 1; i > idx; i-- {
+		n = n.prev
+	}
+	return n
+}
+
+// linkFront links an unlinked node in at the front.
+func (nl *List[E]) linkFront(n *Node[E]) {
+	n.prev, n.next = nil, nl.head
+	if nl.head == nil {
+		nl.tail = n
+	} else {
+		nl.head.prev = n
+	}
+	nl.head = n
+}
+
+// linkBack links an unlinked node in at the back.
+func (nl *List[E]) linkBack(n *Node[E]) {
+	n.next, n.prev = nil, nl.tail
+	if nl.tail == nil {
+		nl.head = n
+	} else {
+		nl.tail.next = n
+	}
+	nl.tail = n
+}
+
+// unlink takes the node out of the chain without changing the length.
+func (nl *List[E]) unlink(n *Node[E]) {
+	if n.prev == nil {
+		nl.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		nl.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+	n.next, n.prev = nil, nil
+}
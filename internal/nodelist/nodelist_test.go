@@ -0,0 +1,157 @@
+package nodelist
+
+import "testing"
+
+// checkList checks the list holds exactly the given ints, following the
+// links both ways.
+func checkList(t *testing.T, nl *List[int], expected ...int) {
+	t.Helper()
+
+	if nl.Len() != len(expected) {
+		t.Fatalf("Wrong length. Expected: %d, Actual: %d", len(expected), nl.Len())
+	}
+
+	idx := 0
+	for n := nl.Front(); n != nil; n = n.Next() {
+		if idx >= len(expected) || n.Elt != expected[idx] {
+			t.Fatalf("Wrong elements going forward at %d. Expected: %v", idx, expected)
+		}
+		idx++
+	}
+	for n := nl.Back(); n != nil; n = n.Prev() {
+		idx--
+		if idx < 0 || n.Elt != expected[idx] {
+			t.Fatalf("Wrong elements going backward at %d. Expected: %v", idx, expected)
+		}
+	}
+	if idx != 0 {
+		t.Fatalf("The links don't match. Expected: %v", expected)
+	}
+}
+
+// makeIntList returns a List holding the given ints.
+func makeIntList(r *Recycler[int], elts ...int) *List[int] {
+	nl := MakeList(r)
+	for _, elt := range elts {
+		nl.PushBack(elt)
+	}
+
+	return nl
+}
+
+func TestListPushRemove(t *testing.T) {
+	nl := MakeList[int](nil)
+	nl.PushBack(1)
+	nl.PushFront(0)
+	last := nl.PushBack(2)
+	checkList(t, nl, 0, 1, 2)
+
+	if elt := nl.Remove(nl.Front().Next()); elt != 1 {
+		t.Errorf("Remove should return the node's element. Expected: %d, Actual: %v", 1, elt)
+	}
+	checkList(t, nl, 0, 2)
+
+	nl.MoveToFront(last)
+	checkList(t, nl, 2, 0)
+	nl.MoveToBack(last)
+	checkList(t, nl, 0, 2)
+
+	nl.Remove(nl.Front())
+	nl.Remove(nl.Front())
+	checkList(t, nl)
+
+	nl.PushBack(3)
+	nl.Init()
+	checkList(t, nl)
+}
+
+func TestListAppend(t *testing.T) {
+	nl := makeIntList(nil, 0, 1)
+	other := makeIntList(nil, 2, 3)
+
+	nl.Append(other)
+	checkList(t, nl, 0, 1, 2, 3)
+	checkList(t, other)
+
+	empty := MakeList[int](nil)
+	empty.Append(nl)
+	checkList(t, empty, 0, 1, 2, 3)
+
+	empty.Append(empty)
+	checkList(t, empty, 0, 1, 2, 3)
+}
+
+func TestListSplitAt(t *testing.T) {
+	for idx := 0; idx <= 5; idx++ {
+		nl := makeIntList(nil, 0, 1, 2, 3, 4)
+		rest := nl.SplitAt(idx)
+
+		var front, back []int
+		for i := 0; i < 5; i++ {
+			if i < idx {
+				front = append(front, i)
+			} else {
+				back = append(back, i)
+			}
+		}
+		checkList(t, nl, front...)
+		checkList(t, rest, back...)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SplitAt past the end should panic.")
+		}
+	}()
+	makeIntList(nil, 0).SplitAt(2)
+}
+
+func TestListReverseRotate(t *testing.T) {
+	nl := makeIntList(nil, 0, 1, 2, 3)
+
+	nl.Reverse()
+	checkList(t, nl, 3, 2, 1, 0)
+
+	nl.Rotate(1)
+	checkList(t, nl, 2, 1, 0, 3)
+
+	nl.Rotate(3)
+	checkList(t, nl, 3, 2, 1, 0)
+
+	nl.Rotate(0)
+	nl.Rotate(4)
+	checkList(t, nl, 3, 2, 1, 0)
+
+	empty := MakeList[int](nil)
+	empty.Reverse()
+	checkList(t, empty)
+}
+
+func TestRecycler(t *testing.T) {
+	r := MakeRecycler[int]()
+	nl := MakeList(r)
+	if nl.Recycler() != r {
+		t.Error("The list should keep its recycler.")
+	}
+
+	elt := 1
+	nl.Remove(nl.PushBack(elt))
+	allocs := testing.AllocsPerRun(100, func() {
+		nl.Remove(nl.PushBack(elt))
+	})
+	if allocs != 0 {
+		t.Errorf("Pushing after a removal should reuse the node. Expected: %d, Actual: %v", 0, allocs)
+	}
+
+	n := nl.PushBack(elt)
+	nl.Remove(n)
+	if n.Elt != 0 || n.Next() != nil || n.Prev() != nil {
+		t.Error("Recycled nodes should be zeroed.")
+	}
+
+	var none *Recycler[int]
+	if n := none.get(elt); n == nil || n.Elt != elt {
+		t.Error("A nil recycler should allocate nodes.")
+	}
+	none.put(&Node[int]{})
+}
@@ -0,0 +1,86 @@
+package adts
+
+import "errors"
+
+// ErrConcurrentModification is returned by a fail-fast iterator's Err when
+// its container was structurally changed (by anything other than the
+// iterator's own Remove) while it was being iterated.
+var ErrConcurrentModification = errors.New("container modified during iteration")
+
+// Iterator walks the elements of a container. Call Next before each element
+// and stop once it returns false, then check Err to see whether the walk
+// finished or was cut short.
+//
+//	for it := list.Iterator(); it.Next(); {
+//		use(it.Value())
+//	}
+type Iterator interface {
+	// Next moves to the next element and returns false once there are none.
+	Next() bool
+	// Value returns the current element.
+	Value() ContainerElement
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
+}
+
+// MutableIterator is a fail-fast Iterator that can also remove the element
+// it's on. Next stops and Err returns ErrConcurrentModification as soon as
+// the container is structurally changed by anything other than the
+// iterator's own Remove.
+type MutableIterator interface {
+	Iterator
+	// Remove removes the current element from the container and returns
+	// whether it did. It can only be called once per call to Next.
+	Remove() bool
+}
+
+// CheckModCount is used by fail-fast iterators to compare the modification
+// count they expect with the container's. It returns nil if they match and
+// ErrConcurrentModification otherwise, or panics in Debug mode.
+func CheckModCount(expected, actual uint64) error {
+	if expected == actual {
+		return nil
+	}
+	if Debug {
+		panic(ErrConcurrentModification)
+	}
+
+	return ErrConcurrentModification
+}
+
+// SliceIterator is an Iterator over a slice of elements. The slice must not
+// be changed while it's being iterated, which makes it a natural snapshot
+// iterator for containers that hand it a copy.
+type SliceIterator struct {
+	elts []ContainerElement
+	idx  int
+}
+
+// MakeSliceIterator creates an iterator over the given elements.
+func MakeSliceIterator(elts []ContainerElement) *SliceIterator {
+	return &SliceIterator{elts, -1}
+}
+
+// Next moves to the next element and returns false once there are none.
+func (si *SliceIterator) Next() bool {
+	if si.idx < len(si.elts) {
+		si.idx++
+	}
+
+	return si.idx < len(si.elts)
+}
+
+// Value returns the current element, or an EmptyContainerElement if the
+// iterator isn't on an element.
+func (si *SliceIterator) Value() ContainerElement {
+	if si.idx < 0 || si.idx >= len(si.elts) {
+		return EmptyContainerElement{}
+	}
+
+	return si.elts[si.idx]
+}
+
+// Err always returns nil since a slice can't change underneath the iterator.
+func (si *SliceIterator) Err() error {
+	return nil
+}
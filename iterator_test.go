@@ -0,0 +1,42 @@
+package adts
+
+import "testing"
+
+func TestSliceIterator(t *testing.T) {
+	it := MakeSliceIterator([]ContainerElement{IntElt(0), IntElt(1), IntElt(2)})
+
+	if _, ok := it.Value().(EmptyContainerElement); !ok {
+		t.Error("Value before Next should be empty.")
+	}
+
+	count := 0
+	for it.Next() {
+		if !it.Value().Equals(IntElt(count)) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", count, it.Value())
+		}
+		count++
+	}
+
+	if count != 3 || it.Next() || it.Err() != nil {
+		t.Errorf("Iterator should stop after every element. Expected: %d, Actual: %d", 3, count)
+	}
+	if _, ok := it.Value().(EmptyContainerElement); !ok {
+		t.Error("Value after the end should be empty.")
+	}
+}
+
+func TestCheckModCount(t *testing.T) {
+	if err := CheckModCount(3, 3); err != nil {
+		t.Errorf("Matching counts should pass. Expected: %v, Actual: %v", nil, err)
+	}
+
+	defer func() {
+		r := recover()
+		if Debug && r != ErrConcurrentModification {
+			t.Errorf("CheckModCount should panic in debug mode. Expected: %v, Actual: %v", ErrConcurrentModification, r)
+		}
+	}()
+	if err := CheckModCount(3, 4); err != ErrConcurrentModification {
+		t.Errorf("Different counts should fail. Expected: %v, Actual: %v", ErrConcurrentModification, err)
+	}
+}
@@ -0,0 +1,600 @@
+package listadts
+
+import (
+	"math"
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// noNode is the index used for a missing node, like a nil pointer.
+const noNode int32 = -1
+
+// arenaNode is a node of an ArenaList. Free nodes are chained through next.
+type arenaNode struct {
+	elt  adts.ContainerElement
+	next int32
+}
+
+// ArenaList is a singly linked list whose nodes live in one slice and link
+// to each other by index. Adding an element only allocates when the slice
+// has to grow, and removed nodes go on a free list to be reused, so a list
+// under constant churn stops allocating altogether. After heavy churn the
+// nodes end up scattered through the slice; Compact puts them back in order.
+type ArenaList struct {
+	nodes      []arenaNode
+	head       int32
+	tail       int32
+	free       int32
+	len        int
+	lock       *sync.RWMutex
+	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+	modCount   uint64
+}
+
+// NewArenaList creates a new ArenaList configured by the given options.
+// Capacity preallocates room for that many nodes. ShrinkFactor doesn't
+// apply and is ignored.
+func NewArenaList(opts ...adts.Option) *ArenaList {
+	o := adts.MakeOptions(opts...)
+	nodes := make([]arenaNode, 0, o.Capacity)
+	return &ArenaList{nodes, noNode, noNode, noNode, 0, &sync.RWMutex{}, o.ThreadSafe, o.Metrics, o.Equal, 0}
+}
+
+// MakeArenaList creates a new non-threadsafe ArenaList. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the list.
+func MakeArenaList(eq ...adts.EqualFunc) *ArenaList {
+	return NewArenaList(adts.WithEquality(firstEqual(eq)))
+}
+
+// MakeArenaListThreadSafe creates a new threadsafe ArenaList. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the list.
+func MakeArenaListThreadSafe(eq ...adts.EqualFunc) *ArenaList {
+	return NewArenaList(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the list, which then records how
+// long it waits on its lock. It must be called before the list is shared
+// between goroutines.
+func (l *ArenaList) SetMetrics(m *adts.Metrics) {
+	l.metrics = m
+}
+
+// alloc returns the index of a node holding elt, reusing a free node if
+// there is one.
+func (l *ArenaList) alloc(elt adts.ContainerElement) int32 {
+	if l.free != noNode {
+		idx := l.free
+		l.free = l.nodes[idx].next
+		l.nodes[idx] = arenaNode{elt, noNode}
+		return idx
+	}
+
+	if len(l.nodes) > math.MaxInt32 {
+		panic("arena list is full")
+	}
+
+	l.nodes = append(l.nodes, arenaNode{elt, noNode})
+	return int32(len(l.nodes) - 1)
+}
+
+// release puts the node at idx on the free list.
+func (l *ArenaList) release(idx int32) {
+	l.nodes[idx] = arenaNode{nil, l.free}
+	l.free = idx
+}
+
+// unlink removes the node after prev (or the head if prev is noNode) from
+// the list and frees it.
+func (l *ArenaList) unlink(prev, idx int32) {
+	if prev == noNode {
+		l.head = l.nodes[idx].next
+	} else {
+		l.nodes[prev].next = l.nodes[idx].next
+	}
+	if idx == l.tail {
+		l.tail = prev
+	}
+
+	l.release(idx)
+	l.len--
+	l.modCount++
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (l *ArenaList) Len() int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.len
+	}
+
+	return l.len
+}
+
+// IsEmpty returns if the list is empty or not.
+func (l *ArenaList) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+// Clear removes all elements from the list. The node slice keeps its
+// capacity.
+func (l *ArenaList) Clear() {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		l.clearHelper()
+		return
+	}
+
+	l.clearHelper()
+}
+
+// clearHelper empties the node slice and the free list.
+func (l *ArenaList) clearHelper() {
+	clear(l.nodes)
+	l.nodes = l.nodes[:0]
+	l.head, l.tail, l.free = noNode, noNode, noNode
+	l.len = 0
+	l.modCount++
+}
+
+// Contains returns true if the given item is in the list.
+func (l *ArenaList) Contains(item adts.ContainerElement) bool {
+	return l.IndexOf(item) >= 0
+}
+
+// Add returns true if the given element was appended to the end of the list.
+func (l *ArenaList) Add(item adts.ContainerElement) bool {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.addHelper(item)
+	}
+
+	return l.addHelper(item)
+}
+
+// addHelper links a new node after the tail.
+func (l *ArenaList) addHelper(item adts.ContainerElement) bool {
+	idx := l.alloc(item)
+	if l.tail == noNode {
+		l.head = idx
+	} else {
+		l.nodes[l.tail].next = idx
+	}
+	l.tail = idx
+
+	l.len++
+	l.modCount++
+	return true
+}
+
+// Remove removes the first element equal to the given item and returns
+// whether an element was removed.
+func (l *ArenaList) Remove(item adts.ContainerElement) bool {
+	_, ok := l.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (l *ArenaList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.removeHelper(item)
+	}
+
+	return l.removeHelper(item)
+}
+
+// removeHelper searches the list for the element and unlinks its node.
+func (l *ArenaList) removeHelper(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	prev := noNode
+	for idx := l.head; idx != noNode; prev, idx = idx, l.nodes[idx].next {
+		if elt := l.nodes[idx].elt; l.equal.Equal(elt, item) {
+			l.unlink(prev, idx)
+			return elt, true
+		}
+	}
+
+	return adts.EmptyContainerElement{}, false
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (l *ArenaList) IndexOf(item adts.ContainerElement) int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.indexOfHelper(item)
+	}
+
+	return l.indexOfHelper(item)
+}
+
+// indexOfHelper walks the list looking for the given element.
+func (l *ArenaList) indexOfHelper(item adts.ContainerElement) int {
+	pos := 0
+	for idx := l.head; idx != noNode; idx = l.nodes[idx].next {
+		if l.equal.Equal(l.nodes[idx].elt, item) {
+			return pos
+		}
+		pos++
+	}
+
+	return -1
+}
+
+// nodeAt returns the slice index of the node at the given list index.
+func (l *ArenaList) nodeAt(pos int) int32 {
+	if pos < 0 || pos >= l.len {
+		panic("index out of range")
+	}
+
+	idx := l.head
+	for i := 0; i < pos; i++ {
+		idx = l.nodes[idx].next
+	}
+
+	return idx
+}
+
+// Get returns the element at the given index.
+func (l *ArenaList) Get(idx int) adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.nodes[l.nodeAt(idx)].elt
+	}
+
+	return l.nodes[l.nodeAt(idx)].elt
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (l *ArenaList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.setHelper(idx, newVal)
+	}
+
+	return l.setHelper(idx, newVal)
+}
+
+// setHelper finds the node at idx and replaces its element.
+func (l *ArenaList) setHelper(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	node := &l.nodes[l.nodeAt(idx)]
+	oldVal := node.elt
+	node.elt = newVal
+	return oldVal
+}
+
+// -------------------------------------------------------
+// Arena Methods
+// -------------------------------------------------------
+
+// Compact moves the nodes into list order at the front of a new slice that's
+// just big enough for them, dropping the free list. Walking the list then
+// reads the slice from start to end.
+func (l *ArenaList) Compact() {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		l.compactHelper()
+		return
+	}
+
+	l.compactHelper()
+}
+
+// compactHelper copies the nodes in list order into a new slice.
+func (l *ArenaList) compactHelper() {
+	nodes := make([]arenaNode, l.len)
+	pos := int32(0)
+	for idx := l.head; idx != noNode; idx = l.nodes[idx].next {
+		nodes[pos] = arenaNode{l.nodes[idx].elt, pos + 1}
+		pos++
+	}
+
+	l.nodes = nodes
+	l.head, l.tail, l.free = noNode, noNode, noNode
+	if l.len > 0 {
+		nodes[l.len-1].next = noNode
+		l.head, l.tail = 0, int32(l.len-1)
+	}
+	l.modCount++
+}
+
+// Free returns the number of nodes waiting on the free list.
+func (l *ArenaList) Free() int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return len(l.nodes) - l.len
+	}
+
+	return len(l.nodes) - l.len
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the list. Its Err returns
+// adts.ErrConcurrentModification if the list is changed other than through
+// the iterator's Remove.
+func (l *ArenaList) Iterator() adts.MutableIterator {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+	}
+
+	return &arenaListIterator{l: l, prev: noNode, cur: noNode, expected: l.modCount}
+}
+
+// arenaListIterator is the fail-fast iterator of an ArenaList. It keeps the
+// node before the current one so Remove can unlink the current node.
+type arenaListIterator struct {
+	l         *ArenaList
+	prev      int32
+	cur       int32
+	val       adts.ContainerElement
+	started   bool
+	removed   bool
+	expected  uint64
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the list has been changed.
+func (it *arenaListIterator) Next() bool {
+	if it.l.threadSafe {
+		it.l.metrics.Lock(it.l.lock.RLocker())
+		defer it.l.lock.RUnlock()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.l.modCount); it.err != nil {
+		return false
+	}
+
+	switch {
+	case !it.started:
+		it.cur, it.started = it.l.head, true
+	case it.removed:
+		// cur was unlinked, so the next node hangs off prev (or the head).
+		if it.prev == noNode {
+			it.cur = it.l.head
+		} else {
+			it.cur = it.l.nodes[it.prev].next
+		}
+		it.removed = false
+	case it.cur != noNode:
+		it.prev, it.cur = it.cur, it.l.nodes[it.cur].next
+	}
+
+	if it.cur == noNode {
+		it.val = nil
+		return false
+	}
+
+	it.val = it.l.nodes[it.cur].elt
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *arenaListIterator) Value() adts.ContainerElement {
+	if it.val == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.val
+}
+
+// Err returns adts.ErrConcurrentModification if the list was changed during
+// iteration.
+func (it *arenaListIterator) Err() error {
+	return it.err
+}
+
+// Remove unlinks the current element from the list.
+func (it *arenaListIterator) Remove() bool {
+	if it.l.threadSafe {
+		it.l.metrics.Lock(it.l.lock)
+		defer it.l.lock.Unlock()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.l.modCount); it.err != nil {
+		return false
+	}
+
+	it.l.unlink(it.prev, it.cur)
+	it.expected = it.l.modCount
+	it.removable = false
+	it.removed = true
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a compacted copy of the list with the same settings.
+// Elements that implement adts.Cloner are cloned.
+func (l *ArenaList) Clone() *ArenaList {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.cloneHelper()
+	}
+
+	return l.cloneHelper()
+}
+
+// cloneHelper copies the list in order without locking.
+func (l *ArenaList) cloneHelper() *ArenaList {
+	clone := &ArenaList{make([]arenaNode, 0, l.len), noNode, noNode, noNode, 0, &sync.RWMutex{}, l.threadSafe, l.metrics, l.equal, 0}
+	for idx := l.head; idx != noNode; idx = l.nodes[idx].next {
+		clone.addHelper(adts.CloneElement(l.nodes[idx].elt))
+	}
+
+	return clone
+}
+
+// Snapshot returns a copy of the list's elements in order. The elements
+// themselves aren't cloned.
+func (l *ArenaList) Snapshot() []adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.snapshotHelper()
+	}
+
+	return l.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (l *ArenaList) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, l.len)
+	for idx := l.head; idx != noNode; idx = l.nodes[idx].next {
+		elts = append(elts, l.nodes[idx].elt)
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the list. The lock
+// (if threadsafe) is only held while the snapshot is copied.
+func (l *ArenaList) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(l.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the list that shares its nodes.
+// Any changes made through the view must be copied back with commit.
+func (l *ArenaList) unlocked() *ArenaList {
+	return &ArenaList{l.nodes, l.head, l.tail, l.free, l.len, l.lock, false, l.metrics, l.equal, l.modCount}
+}
+
+// commit copies the state of the given view back into the list.
+func (l *ArenaList) commit(view *ArenaList) {
+	l.nodes = view.nodes
+	l.head = view.head
+	l.tail = view.tail
+	l.free = view.free
+	l.len = view.len
+	l.modCount = view.modCount
+}
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the list. Changes made before fn returns an error
+// are kept.
+func (l *ArenaList) Update(fn func(tx List) error) error {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.updateHelper(fn)
+	}
+
+	return l.updateHelper(fn)
+}
+
+// updateHelper runs fn against a view of the list and then copies the
+// view's state back into the list.
+func (l *ArenaList) updateHelper(fn func(tx List) error) error {
+	view := l.unlocked()
+	defer l.commit(view)
+	return fn(view)
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the list. fn must not modify the view.
+func (l *ArenaList) View(fn func(tx List) error) error {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return fn(l.unlocked())
+	}
+
+	return fn(l.unlocked())
+}
+
+// Atomically runs fn as a single atomic operation on the list.
+func (l *ArenaList) Atomically(fn func(tx adts.Container) error) error {
+	return l.Update(func(tx List) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent appends the given element only if it isn't already in the
+// list and returns whether it was added.
+func (l *ArenaList) AddIfAbsent(item adts.ContainerElement) bool {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.indexOfHelper(item) < 0 && l.addHelper(item)
+	}
+
+	return l.indexOfHelper(item) < 0 && l.addHelper(item)
+}
+
+// CompareAndSet sets the element at the given index to newVal only if the
+// current element equals oldVal, and returns whether the element was set.
+func (l *ArenaList) CompareAndSet(idx int, oldVal, newVal adts.ContainerElement) bool {
+	swapped := false
+	l.Update(func(tx List) error {
+		swapped = compareAndSetHelper(tx, idx, oldVal, newVal, l.equal)
+		return nil
+	})
+
+	return swapped
+}
+
+// ReplaceAll replaces every element equal to oldVal with newVal and returns
+// the number of elements replaced.
+func (l *ArenaList) ReplaceAll(oldVal, newVal adts.ContainerElement) int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.replaceAllHelper(oldVal, newVal)
+	}
+
+	return l.replaceAllHelper(oldVal, newVal)
+}
+
+// replaceAllHelper walks the list once, replacing every matching element.
+func (l *ArenaList) replaceAllHelper(oldVal, newVal adts.ContainerElement) int {
+	replaced := 0
+	for idx := l.head; idx != noNode; idx = l.nodes[idx].next {
+		if l.equal.Equal(l.nodes[idx].elt, oldVal) {
+			l.nodes[idx].elt = newVal
+			replaced++
+		}
+	}
+
+	return replaced
+}
@@ -0,0 +1,210 @@
+package listadts
+
+import (
+	"math/rand"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// checkArenaList checks the list against the expected elements, and that
+// every node is either in the list or on the free list.
+func checkArenaList(t *testing.T, l *ArenaList, expected []adts.ContainerElement) {
+	t.Helper()
+
+	actual := l.Snapshot()
+	if len(actual) != len(expected) || l.Len() != len(expected) {
+		t.Fatalf("Wrong length. Expected: %d, Actual: %d (Len %d)", len(expected), len(actual), l.Len())
+	}
+	for i, elt := range actual {
+		if !elt.Equals(expected[i]) {
+			t.Fatalf("Wrong element at %d. Expected: %v, Actual: %v", i, expected[i], elt)
+		}
+	}
+	if len(expected) > 0 && !l.nodes[l.tail].elt.Equals(expected[len(expected)-1]) {
+		t.Fatalf("Wrong tail. Expected: %v, Actual: %v", expected[len(expected)-1], l.nodes[l.tail].elt)
+	}
+
+	free := 0
+	for idx := l.free; idx != noNode; idx = l.nodes[idx].next {
+		free++
+	}
+	if free != l.Free() || free+l.len != len(l.nodes) {
+		t.Fatalf("Nodes leaked. Expected free: %d, Actual: %d", len(l.nodes)-l.len, free)
+	}
+}
+
+func TestMakeArenaList(t *testing.T) {
+	list := MakeArenaList()
+	if list.threadSafe || !list.IsEmpty() || list.head != noNode || list.Free() != 0 {
+		t.Errorf("Unexpected new list. Actual: %+v", list)
+	}
+
+	if l := NewArenaList(adts.WithThreadSafety(), adts.WithCapacity(16)); !l.threadSafe || cap(l.nodes) != 16 {
+		t.Errorf("NewArenaList didn't apply its options. Expected capacity: %d, Actual: %d", 16, cap(l.nodes))
+	}
+}
+
+func TestArenaListAddGetSetRemove(t *testing.T) {
+	list := MakeArenaListThreadSafe()
+	for i := 0; i < 5; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	if !list.Get(3).Equals(adts.IntElt(3)) || list.IndexOf(adts.IntElt(4)) != 4 {
+		t.Errorf("Wrong element. Expected: %d, Actual: %v", 3, list.Get(3))
+	}
+	if old := list.Set(0, adts.IntElt(9)); !old.Equals(adts.IntElt(0)) {
+		t.Errorf("Set should return the old element. Expected: %d, Actual: %v", 0, old)
+	}
+
+	if !list.Remove(adts.IntElt(4)) || list.Remove(adts.IntElt(4)) {
+		t.Error("Remove should remove an element once.")
+	}
+	list.Add(adts.IntElt(5))
+	checkArenaList(t, list, []adts.ContainerElement{adts.IntElt(9), adts.IntElt(1), adts.IntElt(2), adts.IntElt(3), adts.IntElt(5)})
+
+	list.Clear()
+	checkArenaList(t, list, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Get out of range should panic.")
+		}
+	}()
+	list.Get(0)
+}
+
+func TestArenaListReusesNodes(t *testing.T) {
+	list := MakeArenaList()
+	for i := 0; i < 10; i++ {
+		list.Add(adts.IntElt(i))
+	}
+	for i := 0; i < 10; i += 2 {
+		list.Remove(adts.IntElt(i))
+	}
+	if list.Free() != 5 {
+		t.Errorf("Removed nodes should be freed. Expected: %d, Actual: %d", 5, list.Free())
+	}
+
+	for i := 10; i < 15; i++ {
+		list.Add(adts.IntElt(i))
+	}
+	if len(list.nodes) != 10 || list.Free() != 0 {
+		t.Errorf("Freed nodes should be reused. Expected nodes: %d, Actual: %d", 10, len(list.nodes))
+	}
+
+	var elt adts.ContainerElement = adts.IntElt(0)
+	allocs := testing.AllocsPerRun(100, func() {
+		list.Add(elt)
+		list.Remove(elt)
+	})
+	if allocs != 0 {
+		t.Errorf("Churn shouldn't allocate. Expected: %d, Actual: %v", 0, allocs)
+	}
+}
+
+func TestArenaListCompact(t *testing.T) {
+	list := MakeArenaList()
+	var expected []adts.ContainerElement
+	for i := 0; i < 20; i++ {
+		list.Add(adts.IntElt(i))
+	}
+	for i := 0; i < 20; i++ {
+		if i%3 == 0 {
+			list.Remove(adts.IntElt(i))
+		} else {
+			expected = append(expected, adts.IntElt(i))
+		}
+	}
+
+	it := list.Iterator()
+	list.Compact()
+	checkArenaList(t, list, expected)
+	if len(list.nodes) != len(expected) || cap(list.nodes) != len(expected) || list.Free() != 0 {
+		t.Errorf("Compact should drop free nodes. Expected: %d, Actual: %d", len(expected), cap(list.nodes))
+	}
+	for i := range list.nodes {
+		if i < len(list.nodes)-1 && list.nodes[i].next != int32(i+1) {
+			t.Fatalf("Compacted nodes should be in order. Expected: %d, Actual: %d", i+1, list.nodes[i].next)
+		}
+	}
+	expectConcurrentModification(t, it)
+
+	list.Add(adts.IntElt(20))
+	checkArenaList(t, list, append(expected, adts.IntElt(20)))
+
+	empty := MakeArenaList()
+	empty.Compact()
+	empty.Add(adts.IntElt(1))
+	checkArenaList(t, empty, []adts.ContainerElement{adts.IntElt(1)})
+}
+
+func TestArenaListRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	list := MakeArenaList()
+	var model []adts.ContainerElement
+
+	for op := 0; op < 3000; op++ {
+		elt := adts.IntElt(r.Intn(40))
+		switch r.Intn(5) {
+		case 0, 1:
+			list.Add(elt)
+			model = append(model, elt)
+		case 2:
+			found := false
+			for i, m := range model {
+				if m.Equals(elt) {
+					model = append(model[:i], model[i+1:]...)
+					found = true
+					break
+				}
+			}
+			if list.Remove(elt) != found {
+				t.Fatalf("Remove returned the wrong result for %v. Expected: %t", elt, found)
+			}
+		case 3:
+			if len(model) > 0 {
+				idx := r.Intn(len(model))
+				list.Set(idx, elt)
+				model[idx] = elt
+			}
+		case 4:
+			if r.Intn(10) == 0 {
+				list.Compact()
+			}
+		}
+		checkArenaList(t, list, model)
+	}
+}
+
+func TestArenaListUpdate(t *testing.T) {
+	list := MakeArenaListThreadSafe()
+	list.Update(func(tx List) error {
+		for i := 0; i < 5; i++ {
+			tx.Add(adts.IntElt(i))
+		}
+		tx.Remove(adts.IntElt(0))
+		return nil
+	})
+	checkArenaList(t, list, []adts.ContainerElement{adts.IntElt(1), adts.IntElt(2), adts.IntElt(3), adts.IntElt(4)})
+
+	if !list.CompareAndSet(0, adts.IntElt(1), adts.IntElt(7)) || list.ReplaceAll(adts.IntElt(7), adts.IntElt(8)) != 1 {
+		t.Error("CompareAndSet and ReplaceAll should change the first element.")
+	}
+	if list.AddIfAbsent(adts.IntElt(8)) || !list.AddIfAbsent(adts.IntElt(9)) {
+		t.Error("AddIfAbsent should only add missing elements.")
+	}
+}
+
+func TestArenaListClone(t *testing.T) {
+	testListClone(t, func() List { return MakeArenaListThreadSafe() }, func(l List) List { return l.(*ArenaList).Clone() })
+}
+
+func TestArenaListIterator(t *testing.T) {
+	testIterator(t, MakeArenaList(), []int{0, 1, 2, 3, 4})
+}
+
+func TestArenaListThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeArenaListThreadSafe(), []int{0, 1, 2, 3, 4})
+}
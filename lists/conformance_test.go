@@ -0,0 +1,107 @@
+package listadts_test
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	"github.com/johnsrd7/go-adts/adtstest"
+	listadts "github.com/johnsrd7/go-adts/lists"
+)
+
+func TestSliceListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeSliceList() })
+}
+
+func TestSliceListThreadSafeConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeSliceListThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestSinglyLinkedListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeSinglyLinkedList() })
+}
+
+func TestSinglyLinkedListThreadsafeConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeSinglyLinkedListThreadsafe() }, adtstest.ThreadSafe())
+}
+
+func TestSyncListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List {
+		return listadts.SynchronizedList(listadts.MakeSinglyLinkedList())
+	}, adtstest.ThreadSafe())
+}
+
+func TestObservableListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.ObserveList(listadts.MakeSliceList()) })
+}
+
+func TestInstrumentedListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List {
+		return listadts.InstrumentList(listadts.MakeSliceList(), adts.MakeMetrics())
+	})
+}
+
+func TestSliceListLinearizability(t *testing.T) {
+	adtstest.RunListLinearizability(t, func() listadts.List { return listadts.MakeSliceListThreadSafe() })
+}
+
+func TestSinglyLinkedListLinearizability(t *testing.T) {
+	adtstest.RunListLinearizability(t, func() listadts.List { return listadts.MakeSinglyLinkedListThreadsafe() })
+}
+
+func TestNewSliceListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List {
+		return listadts.NewSliceList(adts.WithThreadSafety(), adts.WithCapacity(8), adts.WithMetrics(adts.MakeMetrics()))
+	}, adtstest.ThreadSafe())
+}
+
+func TestNewSinglyLinkedListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List {
+		return listadts.NewSinglyLinkedList(adts.WithThreadSafety(), adts.WithCapacity(8), adts.WithMetrics(adts.MakeMetrics()))
+	}, adtstest.ThreadSafe())
+}
+
+func TestSliceListCapacityPolicyConformance(t *testing.T) {
+	for _, policy := range []adts.CapacityPolicy{adts.NeverShrink{}, adts.Hysteresis{ShrinkBelow: 0.25, Target: 0.5}, adts.FixedStep{Step: 4}, adts.MemoryBudget{MaxElements: 1 << 20}} {
+		adtstest.RunListSuite(t, func() listadts.List {
+			return listadts.NewSliceList(adts.WithCapacityPolicy(policy))
+		})
+	}
+}
+
+func TestPersistentListBuilderConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakePersistentList().Builder() })
+}
+
+func TestCopyOnWriteListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeCopyOnWriteList() }, adtstest.ThreadSafe())
+}
+
+func TestCopyOnWriteListLinearizability(t *testing.T) {
+	adtstest.RunListLinearizability(t, func() listadts.List { return listadts.MakeCopyOnWriteList() })
+}
+
+func TestUnrolledListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.NewUnrolledList(adts.WithNodeCapacity(4)) })
+}
+
+func TestUnrolledListThreadSafeConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeUnrolledListThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestUnrolledListLinearizability(t *testing.T) {
+	adtstest.RunListLinearizability(t, func() listadts.List {
+		return listadts.NewUnrolledList(adts.WithThreadSafety(), adts.WithNodeCapacity(2))
+	})
+}
+
+func TestArenaListConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeArenaList() })
+}
+
+func TestArenaListThreadSafeConformance(t *testing.T) {
+	adtstest.RunListSuite(t, func() listadts.List { return listadts.MakeArenaListThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestArenaListLinearizability(t *testing.T) {
+	adtstest.RunListLinearizability(t, func() listadts.List { return listadts.MakeArenaListThreadSafe() })
+}
@@ -0,0 +1,282 @@
+package listadts
+
+import (
+	"sync"
+	"sync/atomic"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// CopyOnWriteList is a threadsafe list for data that's read far more often
+// than it's written. Reads load the current snapshot with an atomic load and
+// never lock. Writes are serialized by a mutex, copy the snapshot, change
+// the copy and publish it, so readers never see a partial update and a
+// snapshot never changes once it's published.
+type CopyOnWriteList struct {
+	snapshot atomic.Pointer[[]adts.ContainerElement]
+	lock     *sync.Mutex
+	metrics  *adts.Metrics
+	equal    adts.EqualFunc
+}
+
+// NewCopyOnWriteList creates a new CopyOnWriteList configured by the given
+// options. A CopyOnWriteList is always threadsafe, and Capacity and
+// ShrinkFactor don't apply, so those options are ignored.
+func NewCopyOnWriteList(opts ...adts.Option) *CopyOnWriteList {
+	o := adts.MakeOptions(opts...)
+	l := &CopyOnWriteList{lock: &sync.Mutex{}, metrics: o.Metrics, equal: o.Equal}
+	l.publish([]adts.ContainerElement{})
+	return l
+}
+
+// MakeCopyOnWriteList creates a new CopyOnWriteList. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the list.
+func MakeCopyOnWriteList(eq ...adts.EqualFunc) *CopyOnWriteList {
+	return NewCopyOnWriteList(adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the list, which then records how
+// long writers wait on the lock. It must be called before the list is shared
+// between goroutines.
+func (l *CopyOnWriteList) SetMetrics(m *adts.Metrics) {
+	l.metrics = m
+}
+
+// load returns the current snapshot. It must not be modified.
+func (l *CopyOnWriteList) load() []adts.ContainerElement {
+	return *l.snapshot.Load()
+}
+
+// publish makes the given slice the current snapshot. Callers must hold the
+// lock, except when the list is being created.
+func (l *CopyOnWriteList) publish(elts []adts.ContainerElement) {
+	l.snapshot.Store(&elts)
+}
+
+// copied returns a copy of the current snapshot with room for one more
+// element, for a writer to change.
+func (l *CopyOnWriteList) copied() []adts.ContainerElement {
+	cur := l.load()
+	elts := make([]adts.ContainerElement, len(cur), len(cur)+1)
+	copy(elts, cur)
+	return elts
+}
+
+// Iterator returns an iterator over the elements of the list at this moment.
+// Later changes to the list don't affect it. Unlike SnapshotIterator it
+// doesn't copy anything, since published snapshots never change. The
+// iterator is fail-safe rather than fail-fast: it never reports
+// adts.ErrConcurrentModification.
+func (l *CopyOnWriteList) Iterator() adts.Iterator {
+	return adts.MakeSliceIterator(l.load())
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (l *CopyOnWriteList) Len() int {
+	return len(l.load())
+}
+
+// IsEmpty returns if the list is empty or not.
+func (l *CopyOnWriteList) IsEmpty() bool {
+	return len(l.load()) == 0
+}
+
+// Clear removes all elements from the list.
+func (l *CopyOnWriteList) Clear() {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+	l.publish([]adts.ContainerElement{})
+}
+
+// Contains returns true if the given item is in the list.
+func (l *CopyOnWriteList) Contains(item adts.ContainerElement) bool {
+	return l.IndexOf(item) >= 0
+}
+
+// Add returns true if the given element was appended to the end of the list.
+func (l *CopyOnWriteList) Add(item adts.ContainerElement) bool {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+	l.publish(append(l.copied(), item))
+	return true
+}
+
+// Remove removes the first element equal to the given item and returns
+// whether an element was removed.
+func (l *CopyOnWriteList) Remove(item adts.ContainerElement) bool {
+	_, ok := l.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (l *CopyOnWriteList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+
+	idx := l.indexOfHelper(l.load(), item)
+	if idx < 0 {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	cur := l.load()
+	elts := make([]adts.ContainerElement, 0, len(cur)-1)
+	elts = append(elts, cur[:idx]...)
+	l.publish(append(elts, cur[idx+1:]...))
+	return cur[idx], true
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (l *CopyOnWriteList) IndexOf(item adts.ContainerElement) int {
+	return l.indexOfHelper(l.load(), item)
+}
+
+// indexOfHelper searches the given snapshot for the item.
+func (l *CopyOnWriteList) indexOfHelper(elts []adts.ContainerElement, item adts.ContainerElement) int {
+	for idx, elt := range elts {
+		if l.equal.Equal(elt, item) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// Get returns the element at the given index.
+func (l *CopyOnWriteList) Get(idx int) adts.ContainerElement {
+	return l.load()[idx]
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (l *CopyOnWriteList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+
+	elts := l.copied()
+	oldVal := elts[idx]
+	elts[idx] = newVal
+	l.publish(elts)
+	return oldVal
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a new CopyOnWriteList holding the current elements, with the
+// same settings. Elements that implement adts.Cloner are cloned.
+func (l *CopyOnWriteList) Clone() *CopyOnWriteList {
+	clone := &CopyOnWriteList{lock: &sync.Mutex{}, metrics: l.metrics, equal: l.equal}
+	clone.publish(adts.CloneElements(l.load()))
+	return clone
+}
+
+// Snapshot returns a copy of the list's elements at this moment. The
+// elements themselves aren't cloned.
+func (l *CopyOnWriteList) Snapshot() []adts.ContainerElement {
+	return append([]adts.ContainerElement{}, l.load()...)
+}
+
+// SnapshotIterator returns an iterator over the elements of the list at this
+// moment. It's the same as Iterator.
+func (l *CopyOnWriteList) SnapshotIterator() adts.Iterator {
+	return l.Iterator()
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock, passing it a non-threadsafe
+// copy of the list. The copy is published when fn returns, so readers see
+// either none or all of its changes. Like the other lists, changes made
+// before fn returns an error are kept.
+func (l *CopyOnWriteList) Update(fn func(tx List) error) error {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+
+	tx := MakeSliceList(l.equal)
+	tx.backer.Backer = l.copied()
+	defer func() {
+		l.publish(tx.backer.Backer)
+	}()
+
+	return fn(tx)
+}
+
+// View runs fn against a copy of the current snapshot without locking. fn
+// must not modify the view, but if it does, only the copy changes, so
+// readers of the published snapshot never see it.
+func (l *CopyOnWriteList) View(fn func(tx List) error) error {
+	tx := MakeSliceList(l.equal)
+	tx.backer.Backer = l.copied()
+	return fn(tx)
+}
+
+// Atomically runs fn as a single atomic operation on the list.
+func (l *CopyOnWriteList) Atomically(fn func(tx adts.Container) error) error {
+	return l.Update(func(tx List) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent appends the given element only if it isn't already in the
+// list and returns whether it was added.
+func (l *CopyOnWriteList) AddIfAbsent(item adts.ContainerElement) bool {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+
+	if l.indexOfHelper(l.load(), item) >= 0 {
+		return false
+	}
+
+	l.publish(append(l.copied(), item))
+	return true
+}
+
+// CompareAndSet sets the element at the given index to newVal only if the
+// current element equals oldVal, and returns whether the element was set.
+func (l *CopyOnWriteList) CompareAndSet(idx int, oldVal, newVal adts.ContainerElement) bool {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+
+	if !l.equal.Equal(l.load()[idx], oldVal) {
+		return false
+	}
+
+	elts := l.copied()
+	elts[idx] = newVal
+	l.publish(elts)
+	return true
+}
+
+// ReplaceAll replaces every element equal to oldVal with newVal and returns
+// the number of elements replaced.
+func (l *CopyOnWriteList) ReplaceAll(oldVal, newVal adts.ContainerElement) int {
+	l.metrics.Lock(l.lock)
+	defer l.lock.Unlock()
+
+	elts := l.copied()
+	replaced := 0
+	for idx, elt := range elts {
+		if l.equal.Equal(elt, oldVal) {
+			elts[idx] = newVal
+			replaced++
+		}
+	}
+
+	if replaced > 0 {
+		l.publish(elts)
+	}
+	return replaced
+}
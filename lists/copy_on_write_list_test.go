@@ -0,0 +1,113 @@
+package listadts
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestCopyOnWriteListSnapshotIterator(t *testing.T) {
+	list := MakeCopyOnWriteList()
+	for i := 0; i < 5; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	it := list.Iterator()
+	list.Set(0, adts.IntElt(100))
+	list.Remove(adts.IntElt(1))
+	list.Add(adts.IntElt(5))
+
+	count := 0
+	for it.Next() {
+		if !it.Value().Equals(adts.IntElt(count)) {
+			t.Errorf("Iterator saw a later change. Expected: %d, Actual: %v", count, it.Value())
+		}
+		count++
+	}
+	if count != 5 {
+		t.Errorf("Wrong number of elements. Expected: %d, Actual: %d", 5, count)
+	}
+}
+
+func TestCopyOnWriteListConcurrentReaders(t *testing.T) {
+	list := MakeCopyOnWriteList()
+	list.Add(adts.IntElt(0))
+	list.Add(adts.IntElt(0))
+
+	// A writer keeps both elements equal; readers must never see them differ.
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			list.Update(func(tx List) error {
+				tx.Set(0, adts.IntElt(i))
+				tx.Set(1, adts.IntElt(i))
+				return nil
+			})
+		}
+		close(done)
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				snapshot := list.Snapshot()
+				if !snapshot[0].Equals(snapshot[1]) {
+					t.Errorf("Reader saw a partial update: %v", snapshot)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestCopyOnWriteListCompoundOps(t *testing.T) {
+	list := MakeCopyOnWriteList(lastDigit)
+	list.Add(adts.IntElt(1))
+
+	if list.AddIfAbsent(adts.IntElt(11)) || !list.AddIfAbsent(adts.IntElt(2)) {
+		t.Error("AddIfAbsent should use the list's EqualFunc.")
+	}
+	if !list.CompareAndSet(0, adts.IntElt(21), adts.IntElt(3)) || list.CompareAndSet(0, adts.IntElt(1), adts.IntElt(4)) {
+		t.Error("CompareAndSet should only succeed when the current value matches.")
+	}
+	if list.ReplaceAll(adts.IntElt(13), adts.IntElt(5)) != 1 || list.IndexOf(adts.IntElt(5)) != 0 {
+		t.Error("ReplaceAll didn't replace the matching element.")
+	}
+}
+
+func TestCopyOnWriteListViewDoesNotAlias(t *testing.T) {
+	list := MakeCopyOnWriteList()
+	list.Add(adts.IntElt(1))
+	before := list.Snapshot()
+	it := list.SnapshotIterator()
+
+	list.View(func(tx List) error {
+		tx.Set(0, adts.IntElt(2))
+		return nil
+	})
+
+	if !list.Get(0).Equals(adts.IntElt(1)) || !before[0].Equals(adts.IntElt(1)) {
+		t.Errorf("Changing the view changed the published snapshot. Expected: %d, Actual: %v", 1, list.Get(0))
+	}
+	if !it.Next() || !it.Value().Equals(adts.IntElt(1)) {
+		t.Errorf("A reader saw a change made in View. Expected: %d, Actual: %v", 1, it.Value())
+	}
+}
+
+func TestCopyOnWriteListClone(t *testing.T) {
+	testListClone(t, func() List { return MakeCopyOnWriteList() }, func(l List) List { return l.(*CopyOnWriteList).Clone() })
+}
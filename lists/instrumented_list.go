@@ -0,0 +1,91 @@
+package listadts
+
+import (
+	"time"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// InstrumentedList wraps any List and records the count and latency of every
+// operation, and the high-water mark of its length, in a adts.Metrics.
+type InstrumentedList struct {
+	inner   List
+	metrics *adts.Metrics
+}
+
+// InstrumentList wraps the given list so that its operations are recorded in m.
+func InstrumentList(l List, m *adts.Metrics) *InstrumentedList {
+	return &InstrumentedList{l, m}
+}
+
+// Metrics returns the metrics the wrapper records into.
+func (i *InstrumentedList) Metrics() *adts.Metrics {
+	return i.metrics
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (i *InstrumentedList) Len() int {
+	defer i.metrics.ObserveOp(adts.OpLen, time.Now())
+	return i.inner.Len()
+}
+
+// IsEmpty returns if the list is empty or not.
+func (i *InstrumentedList) IsEmpty() bool {
+	defer i.metrics.ObserveOp(adts.OpIsEmpty, time.Now())
+	return i.inner.IsEmpty()
+}
+
+// Clear removes all elements from the list.
+func (i *InstrumentedList) Clear() {
+	defer i.metrics.ObserveOp(adts.OpClear, time.Now())
+	i.inner.Clear()
+}
+
+// Contains returns true if the given item is in the list.
+func (i *InstrumentedList) Contains(item adts.ContainerElement) bool {
+	defer i.metrics.ObserveOp(adts.OpContains, time.Now())
+	return i.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the list.
+func (i *InstrumentedList) Add(item adts.ContainerElement) bool {
+	start := time.Now()
+	added := i.inner.Add(item)
+	i.metrics.ObserveOp(adts.OpAdd, start)
+	i.metrics.ObserveLen(i.inner.Len())
+	return added
+}
+
+// Remove returns true if the given element was removed.
+func (i *InstrumentedList) Remove(item adts.ContainerElement) bool {
+	defer i.metrics.ObserveOp(adts.OpRemove, time.Now())
+	return i.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (i *InstrumentedList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	defer i.metrics.ObserveOp(adts.OpRemove, time.Now())
+	return adts.RemoveElement(i.inner, item)
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// Get returns the element at the given index.
+func (i *InstrumentedList) Get(idx int) adts.ContainerElement {
+	defer i.metrics.ObserveOp(adts.OpGet, time.Now())
+	return i.inner.Get(idx)
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (i *InstrumentedList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	defer i.metrics.ObserveOp(adts.OpSet, time.Now())
+	return i.inner.Set(idx, newVal)
+}
@@ -0,0 +1,28 @@
+package listadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestInstrumentList(t *testing.T) {
+	m := adts.MakeMetrics()
+	list := MakeSinglyLinkedListThreadsafe()
+	list.SetMetrics(m)
+
+	var l List
+	l = InstrumentList(list, m)
+	for i := 0; i < 10; i++ {
+		l.Add(adts.IntElt(i))
+	}
+	l.Set(0, l.Get(9))
+
+	stats := m.Stats()
+	if stats.Ops["Get"].Count != 1 || stats.Ops["Set"].Count != 1 || stats.HighWater != 10 {
+		t.Errorf("Wrong stats: %v", stats)
+	}
+	if stats.LockWaits == 0 {
+		t.Error("The threadsafe list should have recorded its lock waits.")
+	}
+}
@@ -15,3 +15,34 @@ type List interface {
 	// Add(item) bool
 	// Remove(item) bool
 }
+
+// compareAndSetHelper sets the element at idx to newVal if the current element
+// equals oldVal under eq. It doesn't lock, so it should only be called on a view that
+// is already protected.
+func compareAndSetHelper(l List, idx int, oldVal, newVal adts.ContainerElement, eq adts.EqualFunc) bool {
+	if !eq.Equal(l.Get(idx), oldVal) {
+		return false
+	}
+
+	l.Set(idx, newVal)
+	return true
+}
+
+// firstEqual returns the first of the given functions, or nil if there are
+// none. Constructors use it to take an optional EqualFunc.
+func firstEqual(eq []adts.EqualFunc) adts.EqualFunc {
+	if len(eq) == 0 {
+		return nil
+	}
+
+	return eq[0]
+}
+
+// ListView is the read-only part of the List interface. Every List is a
+// ListView, and immutable lists like PersistentList implement only this.
+type ListView interface {
+	Get(idx int) adts.ContainerElement
+	Len() int
+	IsEmpty() bool
+	Contains(item adts.ContainerElement) bool
+}
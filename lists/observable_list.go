@@ -0,0 +1,102 @@
+package listadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// ObservableList wraps any List and reports every change made through it to the
+// registered listeners. It has the same ordering guarantees as
+// adts.ObservableContainer: changes are serialized by the wrapper and
+// listeners are called synchronously, in order, before the changing method
+// returns.
+type ObservableList struct {
+	inner List
+	lock  *sync.Mutex
+
+	*adts.Notifier
+}
+
+// ObserveList wraps the given list so that its changes can be observed.
+func ObserveList(l List) *ObservableList {
+	return &ObservableList{l, &sync.Mutex{}, adts.MakeNotifier()}
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (o *ObservableList) Len() int {
+	return o.inner.Len()
+}
+
+// IsEmpty returns if the list is empty or not.
+func (o *ObservableList) IsEmpty() bool {
+	return o.inner.IsEmpty()
+}
+
+// Clear removes all elements from the list.
+func (o *ObservableList) Clear() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.inner.Clear()
+	o.Notify(adts.Event{Kind: adts.Cleared, Index: -1})
+}
+
+// Contains returns true if the given item is in the list.
+func (o *ObservableList) Contains(item adts.ContainerElement) bool {
+	return o.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the list.
+func (o *ObservableList) Add(item adts.ContainerElement) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.inner.Add(item) {
+		return false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Added, Elt: item, Index: -1})
+	return true
+}
+
+// Remove returns true if the given element was removed.
+func (o *ObservableList) Remove(item adts.ContainerElement) bool {
+	_, ok := o.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (o *ObservableList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	elt, ok := adts.RemoveElement(o.inner, item)
+	if !ok {
+		return elt, false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Removed, Elt: elt, Index: -1})
+	return elt, true
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// Get returns the element at the given index.
+func (o *ObservableList) Get(idx int) adts.ContainerElement {
+	return o.inner.Get(idx)
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (o *ObservableList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	oldVal := o.inner.Set(idx, newVal)
+	o.Notify(adts.Event{Kind: adts.Set, Elt: newVal, Old: oldVal, Index: idx})
+	return oldVal
+}
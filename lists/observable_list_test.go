@@ -0,0 +1,50 @@
+package listadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestObserveList(t *testing.T) {
+	var l List
+	ol := ObserveList(MakeSliceList())
+	l = ol
+
+	events := []adts.Event{}
+	ol.Subscribe(func(e adts.Event) {
+		events = append(events, e)
+	})
+
+	l.Add(adts.IntElt(1))
+	l.Set(0, adts.IntElt(2))
+
+	if len(events) != 2 {
+		t.Fatalf("Expected %d events, Actual: %d", 2, len(events))
+	}
+	expected := adts.Event{Kind: adts.Set, Elt: adts.IntElt(2), Old: adts.IntElt(1), Index: 0}
+	if events[1] != expected {
+		t.Errorf("Wrong Set event. Expected: %v, Actual: %v", expected, events[1])
+	}
+}
+
+func TestObserveListRemoveReportsRemovedElement(t *testing.T) {
+	for _, l := range []List{
+		MakeSliceList(lastDigit),
+		MakeSinglyLinkedList(lastDigit),
+		MakeUnrolledList(lastDigit),
+		MakeArenaList(lastDigit),
+		MakeCopyOnWriteList(lastDigit),
+	} {
+		ol := ObserveList(SynchronizedList(InstrumentList(l, adts.MakeMetrics())))
+		var removed adts.ContainerElement
+		ol.Subscribe(func(e adts.Event) {
+			removed = e.Elt
+		})
+
+		ol.Add(adts.IntElt(11))
+		if !ol.Remove(adts.IntElt(21)) || removed == nil || !removed.Equals(adts.IntElt(11)) {
+			t.Errorf("%T: the event should carry the removed element. Expected: %v, Actual: %v", l, 11, removed)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package listadts
+
+import (
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// The persistent list is a bit-partitioned vector trie: elements live in
+// leaves of 32, internal nodes have up to 32 children, and the last (partial)
+// leaf is kept outside the trie as the tail so appends are cheap. Every
+// change copies only the path from the root to the leaf it touches, so old
+// and new versions share everything else.
+const (
+	pvBits  = 5
+	pvWidth = 1 << pvBits
+	pvMask  = pvWidth - 1
+)
+
+// pvOwner marks the nodes a builder is allowed to change in place. It must
+// not be zero sized so that every new owner has a distinct address.
+type pvOwner struct {
+	_ byte
+}
+
+// pvNode is a node in the trie. Leaves use elts and internal nodes use
+// children.
+type pvNode struct {
+	owner    *pvOwner
+	children []*pvNode
+	elts     []adts.ContainerElement
+}
+
+// clone returns a copy of the node that belongs to the given owner.
+func (n *pvNode) clone(owner *pvOwner) *pvNode {
+	ret := &pvNode{owner: owner}
+	if n.children != nil {
+		ret.children = make([]*pvNode, len(n.children), pvWidth)
+		copy(ret.children, n.children)
+	}
+	if n.elts != nil {
+		ret.elts = make([]adts.ContainerElement, len(n.elts), pvWidth)
+		copy(ret.elts, n.elts)
+	}
+
+	return ret
+}
+
+// newPath wraps the given leaf in empty internal nodes until it reaches level.
+func newPath(owner *pvOwner, level uint, node *pvNode) *pvNode {
+	if level == 0 {
+		return node
+	}
+
+	return &pvNode{owner: owner, children: []*pvNode{newPath(owner, level-pvBits, node)}}
+}
+
+// tailOffset returns the index of the first element in the tail of a list
+// with count elements.
+func tailOffset(count int) int {
+	if count == 0 {
+		return 0
+	}
+
+	return ((count - 1) >> pvBits) << pvBits
+}
+
+// PersistentList is an immutable list. Add, Set and Remove return a new list
+// and leave the original unchanged, sharing most of their structure with it,
+// so lists can be passed between goroutines without copying or locking. Get
+// and Set take O(log32 n) time, and Add is amortized O(1).
+//
+// PersistentList implements ListView. Use Builder to make many changes in a
+// row without creating a new version for each one.
+type PersistentList struct {
+	count int
+	shift uint
+	root  *pvNode
+	tail  []adts.ContainerElement
+	equal adts.EqualFunc
+}
+
+// NewPersistentList creates an empty PersistentList configured by the given
+// options. Only the equality option applies; a PersistentList never needs
+// locking.
+func NewPersistentList(opts ...adts.Option) *PersistentList {
+	o := adts.MakeOptions(opts...)
+	return &PersistentList{0, pvBits, &pvNode{}, nil, o.Equal}
+}
+
+// MakePersistentList creates an empty PersistentList. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the list.
+func MakePersistentList(eq ...adts.EqualFunc) *PersistentList {
+	return NewPersistentList(adts.WithEquality(firstEqual(eq)))
+}
+
+// PersistentListOf creates a PersistentList holding the given elements.
+func PersistentListOf(elts ...adts.ContainerElement) *PersistentList {
+	b := MakePersistentList().Builder()
+	for _, elt := range elts {
+		b.Add(elt)
+	}
+
+	return b.Build()
+}
+
+// -------------------------------------------------------
+// ListView Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (pl *PersistentList) Len() int {
+	return pl.count
+}
+
+// IsEmpty returns if the list is empty or not.
+func (pl *PersistentList) IsEmpty() bool {
+	return pl.count == 0
+}
+
+// Get returns the element at the given index.
+func (pl *PersistentList) Get(idx int) adts.ContainerElement {
+	if idx < 0 || idx >= pl.count {
+		panic("index out of range")
+	}
+
+	return pl.leafFor(idx)[idx&pvMask]
+}
+
+// Contains returns true if the given item is in the list.
+func (pl *PersistentList) Contains(item adts.ContainerElement) bool {
+	return pl.IndexOf(item) >= 0
+}
+
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (pl *PersistentList) IndexOf(item adts.ContainerElement) int {
+	for base := 0; base < pl.count; base += pvWidth {
+		for i, elt := range pl.leafFor(base) {
+			if pl.equal.Equal(elt, item) {
+				return base + i
+			}
+		}
+	}
+
+	return -1
+}
+
+// leafFor returns the leaf (or tail) that holds the element at idx.
+func (pl *PersistentList) leafFor(idx int) []adts.ContainerElement {
+	if idx >= tailOffset(pl.count) {
+		return pl.tail
+	}
+
+	return trieLeaf(pl.root, pl.shift, idx)
+}
+
+// -------------------------------------------------------
+// Persistent Methods
+// -------------------------------------------------------
+
+// Add returns a new list with the given element appended to the end.
+func (pl *PersistentList) Add(item adts.ContainerElement) *PersistentList {
+	// There's still room in the tail, so only the tail needs copying.
+	if pl.count-tailOffset(pl.count) < pvWidth {
+		newTail := make([]adts.ContainerElement, len(pl.tail)+1, pvWidth)
+		copy(newTail, pl.tail)
+		newTail[len(pl.tail)] = item
+		return &PersistentList{pl.count + 1, pl.shift, pl.root, newTail, pl.equal}
+	}
+
+	// The tail is full, so push it into the trie and start a new one.
+	root, shift := pushTail(nil, pl.count, pl.shift, pl.root, &pvNode{elts: pl.tail})
+	return &PersistentList{pl.count + 1, shift, root, []adts.ContainerElement{item}, pl.equal}
+}
+
+// Set returns a new list with the element at the given index replaced.
+func (pl *PersistentList) Set(idx int, newVal adts.ContainerElement) *PersistentList {
+	if idx < 0 || idx >= pl.count {
+		panic("index out of range")
+	}
+
+	if idx >= tailOffset(pl.count) {
+		newTail := make([]adts.ContainerElement, len(pl.tail), pvWidth)
+		copy(newTail, pl.tail)
+		newTail[idx&pvMask] = newVal
+		return &PersistentList{pl.count, pl.shift, pl.root, newTail, pl.equal}
+	}
+
+	root := assoc(nil, pl.shift, pl.root, idx, newVal)
+	return &PersistentList{pl.count, pl.shift, root, pl.tail, pl.equal}
+}
+
+// Remove returns a new list without the first element equal to the given
+// item, and whether an element was removed. The elements before the removed
+// one are shared with the original list; the ones after it are copied.
+func (pl *PersistentList) Remove(item adts.ContainerElement) (*PersistentList, bool) {
+	idx := pl.IndexOf(item)
+	if idx < 0 {
+		return pl, false
+	}
+
+	return pl.RemoveAt(idx), true
+}
+
+// RemoveAt returns a new list without the element at the given index.
+func (pl *PersistentList) RemoveAt(idx int) *PersistentList {
+	if idx < 0 || idx >= pl.count {
+		panic("index out of range")
+	}
+
+	b := pl.take(idx).Builder()
+	for i := idx + 1; i < pl.count; i++ {
+		b.Add(pl.Get(i))
+	}
+
+	return b.Build()
+}
+
+// Clear returns an empty list that uses the same equality as this one.
+func (pl *PersistentList) Clear() *PersistentList {
+	return &PersistentList{0, pvBits, &pvNode{}, nil, pl.equal}
+}
+
+// take returns a list holding the first n elements. Full leaves are shared
+// and only the nodes along the new right edge are copied.
+func (pl *PersistentList) take(n int) *PersistentList {
+	if n >= pl.count {
+		return pl
+	}
+	if n == 0 {
+		return pl.Clear()
+	}
+
+	newTailOff := tailOffset(n)
+	newTail := make([]adts.ContainerElement, n-newTailOff, pvWidth)
+	copy(newTail, pl.leafFor(n-1))
+
+	if newTailOff == 0 {
+		return &PersistentList{n, pvBits, &pvNode{}, newTail, pl.equal}
+	}
+
+	root, shift := trimNode(pl.root, pl.shift, newTailOff), pl.shift
+	for shift > pvBits && len(root.children) == 1 {
+		root = root.children[0]
+		shift -= pvBits
+	}
+
+	return &PersistentList{n, shift, root, newTail, pl.equal}
+}
+
+// Builder returns a builder that starts with the elements of this list.
+func (pl *PersistentList) Builder() *PersistentListBuilder {
+	tail := make([]adts.ContainerElement, len(pl.tail), pvWidth)
+	copy(tail, pl.tail)
+	return &PersistentListBuilder{pl.count, pl.shift, pl.root, tail, &pvOwner{}, pl.equal}
+}
+
+// -------------------------------------------------------
+// Trie Helpers
+// -------------------------------------------------------
+
+// trieLeaf walks down from root to the leaf that holds the element at idx.
+func trieLeaf(root *pvNode, shift uint, idx int) []adts.ContainerElement {
+	node := root
+	for level := shift; level > 0; level -= pvBits {
+		node = node.children[(idx>>level)&pvMask]
+	}
+
+	return node.elts
+}
+
+// editable returns node itself if it belongs to owner, or a copy that does.
+// A nil owner (a persistent change) always copies.
+func editable(owner *pvOwner, node *pvNode) *pvNode {
+	if owner != nil && node.owner == owner {
+		return node
+	}
+
+	return node.clone(owner)
+}
+
+// pushTail adds a full tail leaf to the trie of a list with count elements,
+// growing the trie by a level if the root is full. It returns the new root
+// and shift.
+func pushTail(owner *pvOwner, count int, shift uint, root, tailNode *pvNode) (*pvNode, uint) {
+	// The root is full when the trie already holds 32^(levels) leaves.
+	if (count >> pvBits) > (1 << shift) {
+		newRoot := &pvNode{owner: owner, children: []*pvNode{root, newPath(owner, shift, tailNode)}}
+		return newRoot, shift + pvBits
+	}
+
+	return pushTailHelper(owner, count, shift, root, tailNode), shift
+}
+
+// pushTailHelper copies (or edits) the path to the rightmost leaf and hangs
+// the tail leaf off it.
+func pushTailHelper(owner *pvOwner, count int, level uint, parent, tailNode *pvNode) *pvNode {
+	ret := editable(owner, parent)
+	subidx := ((count - 1) >> level) & pvMask
+
+	var child *pvNode
+	switch {
+	case level == pvBits:
+		child = tailNode
+	case subidx < len(parent.children):
+		child = pushTailHelper(owner, count, level-pvBits, parent.children[subidx], tailNode)
+	default:
+		child = newPath(owner, level-pvBits, tailNode)
+	}
+
+	if subidx < len(ret.children) {
+		ret.children[subidx] = child
+	} else {
+		ret.children = append(ret.children, child)
+	}
+
+	return ret
+}
+
+// assoc copies (or edits) the path to the leaf holding idx and sets the
+// element there.
+func assoc(owner *pvOwner, level uint, node *pvNode, idx int, val adts.ContainerElement) *pvNode {
+	ret := editable(owner, node)
+	if level == 0 {
+		ret.elts[idx&pvMask] = val
+		return ret
+	}
+
+	subidx := (idx >> level) & pvMask
+	ret.children[subidx] = assoc(owner, level-pvBits, node.children[subidx], idx, val)
+	return ret
+}
+
+// trimNode returns a copy of node that only holds its first cnt elements.
+// cnt is always a multiple of the leaf size, so leaves are never split.
+func trimNode(node *pvNode, level uint, cnt int) *pvNode {
+	childSize := 1 << level
+	n := (cnt + childSize - 1) / childSize
+
+	ret := &pvNode{children: make([]*pvNode, n, pvWidth)}
+	copy(ret.children, node.children[:n])
+	if level > pvBits {
+		ret.children[n-1] = trimNode(node.children[n-1], level-pvBits, cnt-(n-1)*childSize)
+	}
+
+	return ret
+}
@@ -0,0 +1,137 @@
+package listadts
+
+import (
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// PersistentListBuilder is a mutable list used to make a batch of changes to
+// a PersistentList efficiently. It edits the nodes it has already copied in
+// place instead of copying them again for every change, and Build returns
+// the result as a PersistentList.
+//
+// A PersistentListBuilder implements List but isn't threadsafe. It never
+// changes the list it was made from, and it can keep being used after Build
+// without affecting the lists it has already built.
+type PersistentListBuilder struct {
+	count int
+	shift uint
+	root  *pvNode
+	tail  []adts.ContainerElement
+	owner *pvOwner
+	equal adts.EqualFunc
+}
+
+// Build returns a PersistentList holding the builder's current elements.
+func (b *PersistentListBuilder) Build() *PersistentList {
+	tail := make([]adts.ContainerElement, len(b.tail), pvWidth)
+	copy(tail, b.tail)
+
+	// The built list now shares the builder's nodes, so the builder has to
+	// stop editing them in place.
+	b.owner = &pvOwner{}
+	return &PersistentList{b.count, b.shift, b.root, tail, b.equal}
+}
+
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (b *PersistentListBuilder) IndexOf(item adts.ContainerElement) int {
+	for base := 0; base < b.count; base += pvWidth {
+		for i, elt := range b.leafFor(base) {
+			if b.equal.Equal(elt, item) {
+				return base + i
+			}
+		}
+	}
+
+	return -1
+}
+
+// leafFor returns the leaf (or tail) that holds the element at idx.
+func (b *PersistentListBuilder) leafFor(idx int) []adts.ContainerElement {
+	if idx >= tailOffset(b.count) {
+		return b.tail
+	}
+
+	return trieLeaf(b.root, b.shift, idx)
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the builder.
+func (b *PersistentListBuilder) Len() int {
+	return b.count
+}
+
+// IsEmpty returns if the builder is empty or not.
+func (b *PersistentListBuilder) IsEmpty() bool {
+	return b.count == 0
+}
+
+// Clear removes all elements from the builder.
+func (b *PersistentListBuilder) Clear() {
+	b.count = 0
+	b.shift = pvBits
+	b.root = &pvNode{owner: b.owner}
+	b.tail = make([]adts.ContainerElement, 0, pvWidth)
+}
+
+// Contains returns true if the given item is in the builder.
+func (b *PersistentListBuilder) Contains(item adts.ContainerElement) bool {
+	return b.IndexOf(item) >= 0
+}
+
+// Add returns true if the given element was appended to the end of the builder.
+func (b *PersistentListBuilder) Add(item adts.ContainerElement) bool {
+	if b.count-tailOffset(b.count) < pvWidth {
+		b.tail = append(b.tail, item)
+		b.count++
+		return true
+	}
+
+	b.root, b.shift = pushTail(b.owner, b.count, b.shift, b.root, &pvNode{owner: b.owner, elts: b.tail})
+	b.tail = make([]adts.ContainerElement, 1, pvWidth)
+	b.tail[0] = item
+	b.count++
+	return true
+}
+
+// Remove removes the first element equal to the given item and returns
+// whether an element was removed. Like PersistentList.Remove it rebuilds
+// everything after the removed element.
+func (b *PersistentListBuilder) Remove(item adts.ContainerElement) bool {
+	idx := b.IndexOf(item)
+	if idx < 0 {
+		return false
+	}
+
+	*b = *b.Build().RemoveAt(idx).Builder()
+	return true
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// Get returns the element at the given index.
+func (b *PersistentListBuilder) Get(idx int) adts.ContainerElement {
+	if idx < 0 || idx >= b.count {
+		panic("index out of range")
+	}
+
+	return b.leafFor(idx)[idx&pvMask]
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (b *PersistentListBuilder) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	oldVal := b.Get(idx)
+	if idx >= tailOffset(b.count) {
+		b.tail[idx&pvMask] = newVal
+	} else {
+		b.root = assoc(b.owner, b.shift, b.root, idx, newVal)
+	}
+
+	return oldVal
+}
@@ -0,0 +1,159 @@
+package listadts
+
+import (
+	"math/rand"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// checkPersistentList fails the test if list doesn't hold exactly expected.
+func checkPersistentList(t *testing.T, list ListView, expected []int) {
+	t.Helper()
+
+	if list.Len() != len(expected) {
+		t.Fatalf("Wrong length. Expected: %d, Actual: %d", len(expected), list.Len())
+	}
+	for idx, val := range expected {
+		if !list.Get(idx).Equals(adts.IntElt(val)) {
+			t.Fatalf("Wrong element at %d. Expected: %d, Actual: %v", idx, val, list.Get(idx))
+		}
+	}
+}
+
+func TestPersistentListAdd(t *testing.T) {
+	// Crosses the tail, one level and two level boundaries of the trie.
+	var versions []*PersistentList
+	list := MakePersistentList()
+	for i := 0; i < 40000; i++ {
+		if i%1000 == 0 {
+			versions = append(versions, list)
+		}
+		list = list.Add(adts.IntElt(i))
+	}
+
+	expected := make([]int, 40000)
+	for i := range expected {
+		expected[i] = i
+	}
+	checkPersistentList(t, list, expected)
+
+	// Old versions must be unchanged.
+	for v, old := range versions {
+		checkPersistentList(t, old, expected[:v*1000])
+	}
+}
+
+func TestPersistentListSet(t *testing.T) {
+	expected := make([]int, 2000)
+	elts := make([]adts.ContainerElement, 2000)
+	for i := range expected {
+		expected[i] = i
+		elts[i] = adts.IntElt(i)
+	}
+
+	original := PersistentListOf(elts...)
+	list := original
+	for _, idx := range []int{0, 31, 32, 1023, 1024, 1990, 1999} {
+		list = list.Set(idx, adts.IntElt(-idx))
+		expected[idx] = -idx
+	}
+
+	checkPersistentList(t, list, expected)
+	if !original.Get(1024).Equals(adts.IntElt(1024)) || !original.Get(1999).Equals(adts.IntElt(1999)) {
+		t.Error("Set changed the original list.")
+	}
+}
+
+func TestPersistentListRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(99))
+
+	for _, size := range []int{1, 31, 32, 33, 100, 1024, 1056, 1057, 3000} {
+		expected := make([]int, size)
+		list := MakePersistentList()
+		for i := range expected {
+			expected[i] = i
+			list = list.Add(adts.IntElt(i))
+		}
+
+		for len(expected) > 0 {
+			idx := r.Intn(len(expected))
+			next, removed := list.Remove(adts.IntElt(expected[idx]))
+			if !removed {
+				t.Fatalf("Remove of %d failed.", expected[idx])
+			}
+			checkPersistentList(t, list, expected)
+
+			expected = append(expected[:idx:idx], expected[idx+1:]...)
+			checkPersistentList(t, next, expected)
+			list = next
+		}
+
+		if _, removed := list.Remove(adts.IntElt(0)); removed {
+			t.Error("Remove from an empty list should fail.")
+		}
+	}
+}
+
+func TestPersistentListTake(t *testing.T) {
+	list := MakePersistentList()
+	for i := 0; i < 35000; i++ {
+		list = list.Add(adts.IntElt(i))
+	}
+
+	for _, n := range []int{0, 1, 32, 33, 1024, 1056, 1057, 32768, 32800, 32801, 34999} {
+		taken := list.take(n)
+		if taken.Len() != n {
+			t.Fatalf("Wrong length. Expected: %d, Actual: %d", n, taken.Len())
+		}
+		for _, idx := range []int{0, n / 2, n - 1} {
+			if idx >= 0 && idx < n && !taken.Get(idx).Equals(adts.IntElt(idx)) {
+				t.Errorf("take(%d) has the wrong element at %d. Actual: %v", n, idx, taken.Get(idx))
+			}
+		}
+
+		// Appending after take must still line up with the trie.
+		grown := taken
+		for i := n; i < n+100; i++ {
+			grown = grown.Add(adts.IntElt(i))
+		}
+		if !grown.Get(n+99).Equals(adts.IntElt(n+99)) || !list.Get(n).Equals(adts.IntElt(n)) {
+			t.Errorf("Adding after take(%d) went wrong.", n)
+		}
+	}
+}
+
+func TestPersistentListBuilder(t *testing.T) {
+	base := PersistentListOf(adts.IntElt(0), adts.IntElt(1))
+
+	b := base.Builder()
+	for i := 2; i < 100; i++ {
+		b.Add(adts.IntElt(i))
+	}
+	b.Set(0, adts.IntElt(-1))
+	first := b.Build()
+
+	// Editing the builder after Build must not change the built list.
+	b.Set(50, adts.IntElt(-50))
+	b.Set(99, adts.IntElt(-99))
+	second := b.Build()
+
+	checkPersistentList(t, base, []int{0, 1})
+	if !first.Get(0).Equals(adts.IntElt(-1)) || !first.Get(50).Equals(adts.IntElt(50)) || !first.Get(99).Equals(adts.IntElt(99)) {
+		t.Error("Editing a builder changed a list it already built.")
+	}
+	if !second.Get(50).Equals(adts.IntElt(-50)) || !second.Get(99).Equals(adts.IntElt(-99)) {
+		t.Error("Builder lost its edits.")
+	}
+}
+
+func TestPersistentListEqualFunc(t *testing.T) {
+	list := MakePersistentList(lastDigit).Add(adts.IntElt(1)).Add(adts.IntElt(2))
+
+	if list.IndexOf(adts.IntElt(12)) != 1 || !list.Contains(adts.IntElt(21)) {
+		t.Error("PersistentList should use its EqualFunc.")
+	}
+	if next, removed := list.Remove(adts.IntElt(11)); !removed || next.Contains(adts.IntElt(1)) {
+		t.Error("Remove should use the EqualFunc.")
+	}
+}
@@ -17,8 +17,13 @@ type SinglyLinkedList struct {
 	head       *listNode
 	tail       *listNode
 	len        int
-	lock       *sync.Mutex
+	lock       *sync.RWMutex
 	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+
+	// modCount counts structural changes so iterators can detect them.
+	modCount uint64
 }
 
 // makeListNode creates a new listNode object.
@@ -26,14 +31,30 @@ func makeListNode(elt adts.ContainerElement) *listNode {
 	return &listNode{elt, nil}
 }
 
-// MakeSinglyLinkedList creates a non-threadsafe SinglyLinkedList
-func MakeSinglyLinkedList() *SinglyLinkedList {
-	return &SinglyLinkedList{nil, nil, 0, &sync.Mutex{}, false}
+// NewSinglyLinkedList creates a new SinglyLinkedList configured by the given
+// options. Capacity and ShrinkFactor don't apply and are ignored.
+func NewSinglyLinkedList(opts ...adts.Option) *SinglyLinkedList {
+	o := adts.MakeOptions(opts...)
+	return &SinglyLinkedList{nil, nil, 0, &sync.RWMutex{}, o.ThreadSafe, o.Metrics, o.Equal, 0}
+}
+
+// MakeSinglyLinkedList creates a new non-threadsafe SinglyLinkedList. An
+// optional EqualFunc replaces ContainerElement.Equals when searching the list.
+func MakeSinglyLinkedList(eq ...adts.EqualFunc) *SinglyLinkedList {
+	return NewSinglyLinkedList(adts.WithEquality(firstEqual(eq)))
 }
 
-// MakeSinglyLinkedListThreadsafe creates a new SinglyLinkedList that is threadsafe.
-func MakeSinglyLinkedListThreadsafe() *SinglyLinkedList {
-	return &SinglyLinkedList{nil, nil, 0, &sync.Mutex{}, true}
+// MakeSinglyLinkedListThreadsafe creates a new threadsafe SinglyLinkedList. An
+// optional EqualFunc replaces ContainerElement.Equals when searching the list.
+func MakeSinglyLinkedListThreadsafe(eq ...adts.EqualFunc) *SinglyLinkedList {
+	return NewSinglyLinkedList(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the list, which then records how
+// long it waits on its lock. It must be called before the list is shared
+// between goroutines.
+func (l *SinglyLinkedList) SetMetrics(m *adts.Metrics) {
+	l.metrics = m
 }
 
 // -------------------------------------------------------
@@ -43,8 +64,8 @@ func MakeSinglyLinkedListThreadsafe() *SinglyLinkedList {
 // Len returns the number of elements in the list.
 func (l *SinglyLinkedList) Len() int {
 	if l.threadSafe {
-		l.lock.Lock()
-		defer l.lock.Unlock()
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
 		return l.len
 	}
 	return l.len
@@ -53,8 +74,8 @@ func (l *SinglyLinkedList) Len() int {
 // IsEmpty returns if the list is empty or not.
 func (l *SinglyLinkedList) IsEmpty() bool {
 	if l.threadSafe {
-		l.lock.Lock()
-		defer l.lock.Unlock()
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
 		return l.len == 0
 	}
 
@@ -64,23 +85,28 @@ func (l *SinglyLinkedList) IsEmpty() bool {
 // Clear removes all elements from the list.
 func (l *SinglyLinkedList) Clear() {
 	if l.threadSafe {
-		l.lock.Lock()
+		l.metrics.Lock(l.lock)
 		defer l.lock.Unlock()
-		l.head = nil
-		l.tail = nil
-		l.len = 0
+		l.clearHelper()
+		return
 	}
 
+	l.clearHelper()
+}
+
+// clearHelper drops every node from the list.
+func (l *SinglyLinkedList) clearHelper() {
 	l.head = nil
 	l.tail = nil
 	l.len = 0
+	l.modCount++
 }
 
 // Contains returns true if the given item is in the list.
 func (l *SinglyLinkedList) Contains(item adts.ContainerElement) bool {
 	if l.threadSafe {
-		l.lock.Lock()
-		defer l.lock.Unlock()
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
 		return l.containsHelper(item)
 	}
 
@@ -94,7 +120,7 @@ func (l *SinglyLinkedList) containsHelper(item adts.ContainerElement) bool {
 	}
 
 	for tmp := l.head; tmp != nil; tmp = tmp.next {
-		if tmp.elt.Equals(item) {
+		if l.equal.Equal(tmp.elt, item) {
 			return true
 		}
 	}
@@ -105,7 +131,7 @@ func (l *SinglyLinkedList) containsHelper(item adts.ContainerElement) bool {
 // Add returns true if the given element was appended to the end of the list.
 func (l *SinglyLinkedList) Add(item adts.ContainerElement) bool {
 	if l.threadSafe {
-		l.lock.Lock()
+		l.metrics.Lock(l.lock)
 		defer l.lock.Unlock()
 		return l.addHelper(item)
 	}
@@ -127,14 +153,22 @@ func (l *SinglyLinkedList) addHelper(item adts.ContainerElement) bool {
 
 	// Don't forget to update the length.
 	l.len++
+	l.modCount++
 	return true
 }
 
-// Remove removes the element at the given index and
-// returns whether the removal was successful.
+// Remove removes the first element equal to the given item and returns
+// whether an element was removed.
 func (l *SinglyLinkedList) Remove(item adts.ContainerElement) bool {
+	_, ok := l.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (l *SinglyLinkedList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
 	if l.threadSafe {
-		l.lock.Lock()
+		l.metrics.Lock(l.lock)
 		defer l.lock.Unlock()
 		return l.removeHelper(item)
 	}
@@ -144,39 +178,76 @@ func (l *SinglyLinkedList) Remove(item adts.ContainerElement) bool {
 
 // removeHelper searches the list for the given element and then just
 // sets the next links properly to remove the element from the list.
-func (l *SinglyLinkedList) removeHelper(item adts.ContainerElement) bool {
+func (l *SinglyLinkedList) removeHelper(item adts.ContainerElement) (adts.ContainerElement, bool) {
 	if l.head == nil {
-		return false
+		return adts.EmptyContainerElement{}, false
 	}
 
 	// Check if the head is the item to be removed
-	if l.head.elt.Equals(item) {
+	if l.equal.Equal(l.head.elt, item) {
+		elt := l.head.elt
 		l.head = l.head.next
+		if l.head == nil {
+			l.tail = nil
+		}
 		l.len--
-		return true
+		l.modCount++
+		return elt, true
 	}
 
 	for tmp := l.head; tmp.next != nil; tmp = tmp.next {
-		if tmp.next.elt.Equals(item) {
+		if l.equal.Equal(tmp.next.elt, item) {
+			// If we're removing the tail then the node before it becomes
+			// the new tail, otherwise Add would append to a removed node.
+			if tmp.next == l.tail {
+				l.tail = tmp
+			}
+			elt := tmp.next.elt
 			tmp.next = tmp.next.next
 			// Don't forget to update the length.
 			l.len--
-			return true
+			l.modCount++
+			return elt, true
 		}
 	}
 
-	return false
+	return adts.EmptyContainerElement{}, false
 }
 
 // -------------------------------------------------------
 // List Methods
 // -------------------------------------------------------
 
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (l *SinglyLinkedList) IndexOf(item adts.ContainerElement) int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.indexOfHelper(item)
+	}
+
+	return l.indexOfHelper(item)
+}
+
+// indexOfHelper walks the list looking for the given element.
+func (l *SinglyLinkedList) indexOfHelper(item adts.ContainerElement) int {
+	idx := 0
+	for tmp := l.head; tmp != nil; tmp = tmp.next {
+		if l.equal.Equal(tmp.elt, item) {
+			return idx
+		}
+		idx++
+	}
+
+	return -1
+}
+
 // Get returns the element at the given index.
 func (l *SinglyLinkedList) Get(idx int) adts.ContainerElement {
 	if l.threadSafe {
-		l.lock.Lock()
-		defer l.lock.Unlock()
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
 		return l.getHelper(idx)
 	}
 
@@ -202,7 +273,7 @@ func (l *SinglyLinkedList) getHelper(idx int) adts.ContainerElement {
 // and returns the old value that was at the given index.
 func (l *SinglyLinkedList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
 	if l.threadSafe {
-		l.lock.Lock()
+		l.metrics.Lock(l.lock)
 		defer l.lock.Unlock()
 		return l.setHelper(idx, newVal)
 	}
@@ -226,3 +297,455 @@ func (l *SinglyLinkedList) setHelper(idx int, newVal adts.ContainerElement) adts
 
 	panic("index out of range")
 }
+
+// -------------------------------------------------------
+// Relinking Methods
+// -------------------------------------------------------
+
+// Concat moves every element of other to the end of the list in O(1) time
+// and leaves other empty. Both lists are locked (in adts.LockOrder order) if
+// they're threadsafe. It returns false if other is the list itself.
+func (l *SinglyLinkedList) Concat(other *SinglyLinkedList) bool {
+	if other == l {
+		return false
+	}
+
+	defer lockPair(l, other)()
+
+	if other.head == nil {
+		return true
+	}
+
+	if l.head == nil {
+		l.head = other.head
+	} else {
+		l.tail.next = other.head
+	}
+	l.tail = other.tail
+	l.len += other.len
+	l.modCount++
+
+	other.head, other.tail, other.len = nil, nil, 0
+	other.modCount++
+	return true
+}
+
+// SplitAt cuts the list before the given index and returns the elements from
+// that index on as a new list with the same settings. It takes O(idx) time.
+func (l *SinglyLinkedList) SplitAt(idx int) *SinglyLinkedList {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.splitAtHelper(idx)
+	}
+
+	return l.splitAtHelper(idx)
+}
+
+// splitAtHelper finds the node before idx and unlinks everything after it.
+func (l *SinglyLinkedList) splitAtHelper(idx int) *SinglyLinkedList {
+	if idx < 0 || idx > l.len {
+		panic("index out of range")
+	}
+
+	rest := l.emptyLike()
+	if idx == l.len {
+		return rest
+	}
+
+	rest.tail, rest.len = l.tail, l.len-idx
+	if idx == 0 {
+		rest.head = l.head
+		l.head, l.tail = nil, nil
+	} else {
+		prev := l.nodeAt(idx - 1)
+		rest.head = prev.next
+		prev.next = nil
+		l.tail = prev
+	}
+
+	l.len = idx
+	l.modCount++
+	return rest
+}
+
+// nodeAt returns the node at the given index, which must be in range.
+func (l *SinglyLinkedList) nodeAt(idx int) *listNode {
+	tmp := l.head
+	for i := 0; i < idx; i++ {
+		tmp = tmp.next
+	}
+
+	return tmp
+}
+
+// Reverse reverses the order of the list in place.
+func (l *SinglyLinkedList) Reverse() {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		l.reverseHelper()
+		return
+	}
+
+	l.reverseHelper()
+}
+
+// reverseHelper flips every next link and swaps the head and tail.
+func (l *SinglyLinkedList) reverseHelper() {
+	var prev *listNode
+	for tmp := l.head; tmp != nil; {
+		next := tmp.next
+		tmp.next = prev
+		prev, tmp = tmp, next
+	}
+
+	l.head, l.tail = l.tail, l.head
+	l.modCount++
+}
+
+// Rotate moves the first n elements to the end of the list, keeping their
+// order. A negative n moves the last -n elements to the front instead.
+func (l *SinglyLinkedList) Rotate(n int) {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		l.rotateHelper(n)
+		return
+	}
+
+	l.rotateHelper(n)
+}
+
+// rotateHelper closes the list into a ring and cuts it after the n-th node.
+func (l *SinglyLinkedList) rotateHelper(n int) {
+	n = rotation(n, l.len)
+	if n == 0 {
+		return
+	}
+
+	newTail := l.nodeAt(n - 1)
+	l.tail.next = l.head
+	l.head = newTail.next
+	newTail.next = nil
+	l.tail = newTail
+	l.modCount++
+}
+
+// rotation reduces a rotation by n of a list of the given length to the
+// equivalent left rotation in [0, length).
+func rotation(n, length int) int {
+	if length == 0 {
+		return 0
+	}
+
+	n %= length
+	if n < 0 {
+		n += length
+	}
+
+	return n
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the list. Its Err returns
+// adts.ErrConcurrentModification if the list is changed other than through
+// the iterator's Remove.
+func (l *SinglyLinkedList) Iterator() adts.MutableIterator {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+	}
+
+	return &sllIterator{l: l, expected: l.modCount}
+}
+
+// sllIterator is the fail-fast iterator of a SinglyLinkedList. It keeps the
+// node before the current one so Remove can unlink the current node.
+type sllIterator struct {
+	l         *SinglyLinkedList
+	prev      *listNode
+	cur       *listNode
+	started   bool
+	removed   bool
+	expected  uint64
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the list has been changed.
+func (it *sllIterator) Next() bool {
+	if it.l.threadSafe {
+		it.l.metrics.Lock(it.l.lock.RLocker())
+		defer it.l.lock.RUnlock()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.l.modCount); it.err != nil {
+		return false
+	}
+
+	switch {
+	case !it.started:
+		it.cur, it.started = it.l.head, true
+	case it.removed:
+		// cur was unlinked, so the next node hangs off prev (or the head).
+		if it.prev == nil {
+			it.cur = it.l.head
+		} else {
+			it.cur = it.prev.next
+		}
+		it.removed = false
+	case it.cur != nil:
+		it.prev, it.cur = it.cur, it.cur.next
+	}
+
+	if it.cur == nil {
+		return false
+	}
+
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *sllIterator) Value() adts.ContainerElement {
+	if it.cur == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.cur.elt
+}
+
+// Err returns adts.ErrConcurrentModification if the list was changed during
+// iteration.
+func (it *sllIterator) Err() error {
+	return it.err
+}
+
+// Remove unlinks the current element from the list.
+func (it *sllIterator) Remove() bool {
+	if it.l.threadSafe {
+		it.l.metrics.Lock(it.l.lock)
+		defer it.l.lock.Unlock()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.l.modCount); it.err != nil {
+		return false
+	}
+
+	if it.prev == nil {
+		it.l.head = it.cur.next
+	} else {
+		it.prev.next = it.cur.next
+	}
+	if it.cur == it.l.tail {
+		it.l.tail = it.prev
+	}
+	it.l.len--
+	it.l.modCount++
+
+	it.expected = it.l.modCount
+	it.removable = false
+	it.removed = true
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the list with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (l *SinglyLinkedList) Clone() *SinglyLinkedList {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.cloneHelper()
+	}
+
+	return l.cloneHelper()
+}
+
+// cloneHelper copies the list node by node without locking.
+func (l *SinglyLinkedList) cloneHelper() *SinglyLinkedList {
+	clone := l.emptyLike()
+	for tmp := l.head; tmp != nil; tmp = tmp.next {
+		clone.addHelper(adts.CloneElement(tmp.elt))
+	}
+
+	return clone
+}
+
+// emptyLike returns an empty list with the same settings as this one.
+func (l *SinglyLinkedList) emptyLike() *SinglyLinkedList {
+	return &SinglyLinkedList{nil, nil, 0, &sync.RWMutex{}, l.threadSafe, l.metrics, l.equal, 0}
+}
+
+// Snapshot returns a copy of the list's elements in order. The elements
+// themselves aren't cloned.
+func (l *SinglyLinkedList) Snapshot() []adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.snapshotHelper()
+	}
+
+	return l.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (l *SinglyLinkedList) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, l.len)
+	for tmp := l.head; tmp != nil; tmp = tmp.next {
+		elts = append(elts, tmp.elt)
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the list. The lock
+// (if threadsafe) is only held while the snapshot is copied.
+func (l *SinglyLinkedList) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(l.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the list that shares its nodes.
+// Any changes made through the view must be copied back with commit.
+func (l *SinglyLinkedList) unlocked() *SinglyLinkedList {
+	return &SinglyLinkedList{l.head, l.tail, l.len, l.lock, false, l.metrics, l.equal, l.modCount}
+}
+
+// commit copies the state of the given view back into the list.
+func (l *SinglyLinkedList) commit(view *SinglyLinkedList) {
+	l.head = view.head
+	l.tail = view.tail
+	l.len = view.len
+	l.modCount = view.modCount
+}
+
+// lockPair takes the write locks of both lists (if threadsafe) in
+// adts.LockOrder order and returns a function that releases them.
+func lockPair(a, b *SinglyLinkedList) func() {
+	first, second := a, b
+	if adts.LockOrder(b) < adts.LockOrder(a) {
+		first, second = b, a
+	}
+
+	for _, l := range []*SinglyLinkedList{first, second} {
+		if l.threadSafe {
+			l.metrics.Lock(l.lock)
+		}
+	}
+
+	return func() {
+		for _, l := range []*SinglyLinkedList{second, first} {
+			if l.threadSafe {
+				l.lock.Unlock()
+			}
+		}
+	}
+}
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the list. Changes made before fn returns an error
+// are kept.
+func (l *SinglyLinkedList) Update(fn func(tx List) error) error {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.updateHelper(fn)
+	}
+
+	return l.updateHelper(fn)
+}
+
+// updateHelper runs fn against a view of the list and then copies the
+// view's state back into the list.
+func (l *SinglyLinkedList) updateHelper(fn func(tx List) error) error {
+	view := l.unlocked()
+	defer l.commit(view)
+	return fn(view)
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the list. fn must not modify the view.
+func (l *SinglyLinkedList) View(fn func(tx List) error) error {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return fn(l.unlocked())
+	}
+
+	return fn(l.unlocked())
+}
+
+// Atomically runs fn as a single atomic operation on the list.
+func (l *SinglyLinkedList) Atomically(fn func(tx adts.Container) error) error {
+	return l.Update(func(tx List) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent appends the given element only if it isn't already in the
+// list and returns whether it was added.
+func (l *SinglyLinkedList) AddIfAbsent(item adts.ContainerElement) bool {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return !l.containsHelper(item) && l.addHelper(item)
+	}
+
+	return !l.containsHelper(item) && l.addHelper(item)
+}
+
+// CompareAndSet sets the element at the given index to newVal only if the
+// current element equals oldVal, and returns whether the element was set.
+func (l *SinglyLinkedList) CompareAndSet(idx int, oldVal, newVal adts.ContainerElement) bool {
+	swapped := false
+	l.Update(func(tx List) error {
+		swapped = compareAndSetHelper(tx, idx, oldVal, newVal, l.equal)
+		return nil
+	})
+
+	return swapped
+}
+
+// ReplaceAll replaces every element equal to oldVal with newVal and returns
+// the number of elements replaced.
+func (l *SinglyLinkedList) ReplaceAll(oldVal, newVal adts.ContainerElement) int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.replaceAllHelper(oldVal, newVal)
+	}
+
+	return l.replaceAllHelper(oldVal, newVal)
+}
+
+// replaceAllHelper walks the list once, replacing every matching element.
+func (l *SinglyLinkedList) replaceAllHelper(oldVal, newVal adts.ContainerElement) int {
+	replaced := 0
+	for tmp := l.head; tmp != nil; tmp = tmp.next {
+		if l.equal.Equal(tmp.elt, oldVal) {
+			tmp.elt = newVal
+			replaced++
+		}
+	}
+
+	return replaced
+}
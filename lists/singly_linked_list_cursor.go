@@ -0,0 +1,247 @@
+package listadts
+
+import (
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// Cursor walks a SinglyLinkedList forwards and edits it in place. A new
+// cursor starts before the first element; InsertAfter and RemoveAfter then
+// work at the head of the list. Every operation takes O(1) time and the
+// list's lock (if threadsafe).
+//
+// Like the list's iterator a cursor is fail-fast: once the list is
+// structurally changed other than through the cursor, every operation fails
+// and Err returns adts.ErrConcurrentModification. A cursor itself must only
+// be used by one goroutine at a time.
+type Cursor struct {
+	l        *SinglyLinkedList
+	node     *listNode
+	pos      int
+	expected uint64
+	err      error
+}
+
+// Cursor returns a cursor positioned before the first element of the list.
+func (l *SinglyLinkedList) Cursor() *Cursor {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+	}
+
+	return &Cursor{l, nil, -1, l.modCount, nil}
+}
+
+// check returns whether the list is unchanged since the cursor last looked
+// at it. It must be called with the lock held.
+func (c *Cursor) check() bool {
+	if c.err == nil {
+		c.err = adts.CheckModCount(c.expected, c.l.modCount)
+	}
+
+	return c.err == nil
+}
+
+// changed records a structural change made through the cursor. It must be
+// called with the lock held.
+func (c *Cursor) changed() {
+	c.l.modCount++
+	c.expected = c.l.modCount
+}
+
+// after returns the node after the cursor.
+func (c *Cursor) after() *listNode {
+	if c.node == nil {
+		return c.l.head
+	}
+
+	return c.node.next
+}
+
+// Next moves the cursor to the next element. It returns false, and leaves
+// the cursor where it is, if there's no next element.
+func (c *Cursor) Next() bool {
+	if c.l.threadSafe {
+		c.l.metrics.Lock(c.l.lock.RLocker())
+		defer c.l.lock.RUnlock()
+	}
+
+	if !c.check() {
+		return false
+	}
+
+	next := c.after()
+	if next == nil {
+		return false
+	}
+
+	c.node = next
+	c.pos++
+	return true
+}
+
+// Index returns the index of the element under the cursor, or -1 before the
+// first element.
+func (c *Cursor) Index() int {
+	return c.pos
+}
+
+// Value returns the element under the cursor.
+func (c *Cursor) Value() adts.ContainerElement {
+	if c.l.threadSafe {
+		c.l.metrics.Lock(c.l.lock.RLocker())
+		defer c.l.lock.RUnlock()
+	}
+
+	if c.node == nil || !c.check() {
+		return adts.EmptyContainerElement{}
+	}
+
+	return c.node.elt
+}
+
+// SetValue replaces the element under the cursor and returns the old one.
+// It returns false if the cursor is before the first element.
+func (c *Cursor) SetValue(newVal adts.ContainerElement) (adts.ContainerElement, bool) {
+	if c.l.threadSafe {
+		c.l.metrics.Lock(c.l.lock)
+		defer c.l.lock.Unlock()
+	}
+
+	if c.node == nil || !c.check() {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	oldVal := c.node.elt
+	c.node.elt = newVal
+	return oldVal, true
+}
+
+// InsertAfter inserts the given element after the cursor without moving the
+// cursor, and returns whether it was inserted.
+func (c *Cursor) InsertAfter(item adts.ContainerElement) bool {
+	if c.l.threadSafe {
+		c.l.metrics.Lock(c.l.lock)
+		defer c.l.lock.Unlock()
+	}
+
+	if !c.check() {
+		return false
+	}
+
+	newNode := makeListNode(item)
+	newNode.next = c.after()
+	if c.node == nil {
+		c.l.head = newNode
+	} else {
+		c.node.next = newNode
+	}
+	if newNode.next == nil {
+		c.l.tail = newNode
+	}
+
+	c.l.len++
+	c.changed()
+	return true
+}
+
+// RemoveAfter removes the element after the cursor and returns it.
+func (c *Cursor) RemoveAfter() (adts.ContainerElement, bool) {
+	if c.l.threadSafe {
+		c.l.metrics.Lock(c.l.lock)
+		defer c.l.lock.Unlock()
+	}
+
+	if !c.check() {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	removed := c.after()
+	if removed == nil {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	if c.node == nil {
+		c.l.head = removed.next
+	} else {
+		c.node.next = removed.next
+	}
+	if removed == c.l.tail {
+		c.l.tail = c.node
+	}
+
+	c.l.len--
+	c.changed()
+	return removed.elt, true
+}
+
+// Split cuts the list after the cursor and returns the elements after it as
+// a new list with the same settings. The cursor's list keeps the elements up
+// to and including the cursor.
+func (c *Cursor) Split() (*SinglyLinkedList, bool) {
+	if c.l.threadSafe {
+		c.l.metrics.Lock(c.l.lock)
+		defer c.l.lock.Unlock()
+	}
+
+	if !c.check() {
+		return nil, false
+	}
+
+	rest := c.l.emptyLike()
+	if rest.head = c.after(); rest.head != nil {
+		rest.tail = c.l.tail
+		rest.len = c.l.len - (c.pos + 1)
+	}
+
+	if c.node == nil {
+		c.l.head = nil
+	} else {
+		c.node.next = nil
+	}
+	c.l.tail = c.node
+	c.l.len = c.pos + 1
+
+	c.changed()
+	return rest, true
+}
+
+// Splice moves every element of other into the list after the cursor, in
+// order, and leaves other empty. The cursor doesn't move. Both lists are
+// locked (in adts.LockOrder order) if they're threadsafe.
+func (c *Cursor) Splice(other *SinglyLinkedList) bool {
+	if other == c.l {
+		return false
+	}
+
+	defer lockPair(c.l, other)()
+
+	if !c.check() {
+		return false
+	}
+	if other.head == nil {
+		return true
+	}
+
+	other.tail.next = c.after()
+	if c.node == nil {
+		c.l.head = other.head
+	} else {
+		c.node.next = other.head
+	}
+	if other.tail.next == nil {
+		c.l.tail = other.tail
+	}
+	c.l.len += other.len
+
+	other.head, other.tail, other.len = nil, nil, 0
+	other.modCount++
+
+	c.changed()
+	return true
+}
+
+// Err returns adts.ErrConcurrentModification if the list was changed other
+// than through the cursor.
+func (c *Cursor) Err() error {
+	return c.err
+}
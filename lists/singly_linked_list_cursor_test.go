@@ -0,0 +1,227 @@
+package listadts
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// checkElements checks that the list holds exactly the given ints, in order,
+// and that its tail is the last of them.
+func checkElements(t *testing.T, l *SinglyLinkedList, expected ...int) {
+	t.Helper()
+
+	actual := l.Snapshot()
+	if len(actual) != len(expected) || l.Len() != len(expected) {
+		t.Errorf("Wrong length. Expected: %v, Actual: %v (Len %d)", expected, actual, l.Len())
+		return
+	}
+	for i, elt := range actual {
+		if !elt.Equals(adts.IntElt(expected[i])) {
+			t.Errorf("Wrong elements. Expected: %v, Actual: %v", expected, actual)
+			return
+		}
+	}
+	if len(expected) > 0 && !l.tail.elt.Equals(adts.IntElt(expected[len(expected)-1])) {
+		t.Errorf("Wrong tail. Expected: %d, Actual: %v", expected[len(expected)-1], l.tail.elt)
+	}
+	if len(expected) == 0 && (l.head != nil || l.tail != nil) {
+		t.Error("An empty list should have no head or tail.")
+	}
+}
+
+func makeIntList(threadSafe bool, elts ...int) *SinglyLinkedList {
+	l := MakeSinglyLinkedList()
+	if threadSafe {
+		l = MakeSinglyLinkedListThreadsafe()
+	}
+	for _, elt := range elts {
+		l.Add(adts.IntElt(elt))
+	}
+
+	return l
+}
+
+func TestCursorWalk(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		l := makeIntList(threadSafe, 0, 1, 2)
+		c := l.Cursor()
+
+		if _, ok := c.Value().(adts.EmptyContainerElement); !ok || c.Index() != -1 {
+			t.Error("A new cursor should be before the first element.")
+		}
+		if _, ok := c.SetValue(adts.IntElt(9)); ok {
+			t.Error("SetValue before the first element should fail.")
+		}
+
+		for i := 0; c.Next(); i++ {
+			if !c.Value().Equals(adts.IntElt(i)) || c.Index() != i {
+				t.Errorf("Wrong element. Expected: %d, Actual: %v", i, c.Value())
+			}
+		}
+		if !c.Value().Equals(adts.IntElt(2)) {
+			t.Errorf("The cursor should stay on the last element. Expected: %d, Actual: %v", 2, c.Value())
+		}
+
+		if old, ok := c.SetValue(adts.IntElt(5)); !ok || !old.Equals(adts.IntElt(2)) {
+			t.Errorf("SetValue should return the old element. Expected: %d, Actual: %v", 2, old)
+		}
+		checkElements(t, l, 0, 1, 5)
+	}
+}
+
+func TestCursorInsertAfter(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		l := makeIntList(threadSafe)
+		c := l.Cursor()
+
+		c.InsertAfter(adts.IntElt(2))
+		c.InsertAfter(adts.IntElt(0))
+		checkElements(t, l, 0, 2)
+
+		c.Next()
+		c.InsertAfter(adts.IntElt(1))
+		checkElements(t, l, 0, 1, 2)
+
+		for c.Next() {
+		}
+		c.InsertAfter(adts.IntElt(3))
+		l.Add(adts.IntElt(4))
+		checkElements(t, l, 0, 1, 2, 3, 4)
+
+		if c.Err() != nil {
+			t.Errorf("Changes through the cursor shouldn't invalidate it. Actual: %v", c.Err())
+		}
+	}
+}
+
+func TestCursorRemoveAfter(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		l := makeIntList(threadSafe, 0, 1, 2, 3)
+		c := l.Cursor()
+
+		if elt, ok := c.RemoveAfter(); !ok || !elt.Equals(adts.IntElt(0)) {
+			t.Errorf("RemoveAfter should remove the head. Expected: %d, Actual: %v", 0, elt)
+		}
+		checkElements(t, l, 1, 2, 3)
+
+		c.Next()
+		c.Next()
+		if elt, ok := c.RemoveAfter(); !ok || !elt.Equals(adts.IntElt(3)) {
+			t.Errorf("RemoveAfter should remove the tail. Expected: %d, Actual: %v", 3, elt)
+		}
+		if _, ok := c.RemoveAfter(); ok {
+			t.Error("RemoveAfter at the end should fail.")
+		}
+		l.Add(adts.IntElt(4))
+		checkElements(t, l, 1, 2, 4)
+
+		single := makeIntList(threadSafe, 0)
+		single.Cursor().RemoveAfter()
+		checkElements(t, single)
+	}
+}
+
+func TestCursorSplit(t *testing.T) {
+	l := makeIntList(true, 0, 1, 2, 3)
+	c := l.Cursor()
+	c.Next()
+	c.Next()
+
+	rest, ok := c.Split()
+	if !ok {
+		t.Fatal("Split should succeed.")
+	}
+	checkElements(t, l, 0, 1)
+	checkElements(t, rest, 2, 3)
+	if !rest.threadSafe {
+		t.Error("The split off list should keep the list's settings.")
+	}
+
+	if end, _ := c.Split(); !end.IsEmpty() {
+		t.Errorf("Splitting at the end should return an empty list. Actual: %v", end.Snapshot())
+	}
+	checkElements(t, l, 0, 1)
+
+	all, _ := l.Cursor().Split()
+	checkElements(t, l)
+	checkElements(t, all, 0, 1)
+}
+
+func TestCursorSplice(t *testing.T) {
+	l := makeIntList(false, 0, 3)
+	c := l.Cursor()
+	c.Next()
+
+	other := makeIntList(true, 1, 2)
+	if !c.Splice(other) {
+		t.Fatal("Splice should succeed.")
+	}
+	checkElements(t, l, 0, 1, 2, 3)
+	checkElements(t, other)
+
+	for c.Next() {
+	}
+	c.Splice(makeIntList(false, 4))
+	l.Add(adts.IntElt(5))
+	checkElements(t, l, 0, 1, 2, 3, 4, 5)
+
+	l.Cursor().Splice(makeIntList(false, -1))
+	checkElements(t, l, -1, 0, 1, 2, 3, 4, 5)
+
+	if l.Cursor().Splice(l) {
+		t.Error("A list can't be spliced into itself.")
+	}
+}
+
+func TestCursorConcurrentSplice(t *testing.T) {
+	a := makeIntList(true)
+	b := makeIntList(true)
+
+	// Splicing in both directions at once must not deadlock.
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			b.Add(adts.IntElt(1))
+			a.Cursor().Splice(b)
+		}()
+		go func() {
+			defer wg.Done()
+			a.Add(adts.IntElt(1))
+			b.Cursor().Splice(a)
+		}()
+	}
+	wg.Wait()
+
+	if a.Len()+b.Len() != 200 {
+		t.Errorf("Splice lost elements. Expected: %d, Actual: %d", 200, a.Len()+b.Len())
+	}
+}
+
+func TestCursorFailFast(t *testing.T) {
+	l := makeIntList(false, 0, 1)
+	c := l.Cursor()
+	c.Next()
+	l.Remove(adts.IntElt(0))
+
+	func() {
+		if adts.Debug {
+			defer func() {
+				if r := recover(); r != adts.ErrConcurrentModification {
+					t.Errorf("Next should panic in debug mode. Expected: %v, Actual: %v", adts.ErrConcurrentModification, r)
+				}
+			}()
+		}
+		if c.InsertAfter(adts.IntElt(5)) || c.Err() != adts.ErrConcurrentModification {
+			t.Errorf("The cursor should fail after a change. Expected: %v, Actual: %v", adts.ErrConcurrentModification, c.Err())
+		}
+	}()
+	checkElements(t, l, 1)
+
+	it := l.Iterator()
+	l.Cursor().InsertAfter(adts.IntElt(0))
+	expectConcurrentModification(t, it)
+}
@@ -212,3 +212,244 @@ func TestSet(t *testing.T) {
 		}
 	}
 }
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestSinglyLinkedListUpdate(t *testing.T) {
+	var a adts.Atomic
+	a = MakeSinglyLinkedListThreadsafe()
+
+	list := a.(*SinglyLinkedList)
+	list.Update(func(tx List) error {
+		for i := 0; i < 10; i++ {
+			tx.Add(adts.IntElt(i))
+		}
+		return nil
+	})
+
+	if list.Len() != 10 || !list.Get(9).Equals(adts.IntElt(9)) {
+		t.Errorf("Changes made in Update should be kept. Expected length: %d, Actual length: %d", 10, list.Len())
+	}
+
+	// Adding after Update must still append to the right tail.
+	list.Add(adts.IntElt(10))
+	if !list.Get(10).Equals(adts.IntElt(10)) {
+		t.Errorf("Add after Update appended to the wrong place. Actual: %v", list.Get(10))
+	}
+
+	if !list.CompareAndSet(0, adts.IntElt(0), adts.IntElt(100)) || list.CompareAndSet(0, adts.IntElt(0), adts.IntElt(200)) {
+		t.Error("CompareAndSet should only succeed when the current value matches.")
+	}
+	if list.ReplaceAll(adts.IntElt(100), adts.IntElt(0)) != 1 || !list.Get(0).Equals(adts.IntElt(0)) {
+		t.Error("ReplaceAll didn't replace the matching element.")
+	}
+	if list.AddIfAbsent(adts.IntElt(5)) || !list.AddIfAbsent(adts.IntElt(50)) {
+		t.Error("AddIfAbsent should only add elements that aren't in the list.")
+	}
+}
+
+// lastDigit compares IntElts by their last digit.
+var lastDigit = adts.EqualBy(func(e adts.ContainerElement) int {
+	i, _ := e.(adts.IntElt)
+	return int(i) % 10
+})
+
+func TestSinglyLinkedListEqualFunc(t *testing.T) {
+	for _, list := range []*SinglyLinkedList{MakeSinglyLinkedList(lastDigit), MakeSinglyLinkedListThreadsafe(lastDigit)} {
+		for i := 0; i < 5; i++ {
+			list.Add(adts.IntElt(i))
+		}
+
+		if idx := list.IndexOf(adts.IntElt(13)); idx != 3 {
+			t.Errorf("IndexOf should use the list's EqualFunc. Expected: %d, Actual: %d", 3, idx)
+		}
+		if list.IndexOf(adts.IntElt(9)) != -1 {
+			t.Error("IndexOf should return -1 for missing elements.")
+		}
+		if !list.Contains(adts.IntElt(14)) || !list.Remove(adts.IntElt(24)) || list.Len() != 4 {
+			t.Error("Contains and Remove should use the list's EqualFunc.")
+		}
+		if !list.CompareAndSet(0, adts.IntElt(10), adts.IntElt(7)) {
+			t.Error("CompareAndSet should use the list's EqualFunc.")
+		}
+		if list.ReplaceAll(adts.IntElt(17), adts.IntElt(8)) != 1 {
+			t.Error("ReplaceAll should use the list's EqualFunc.")
+		}
+	}
+}
+
+// testListClone checks that clones and snapshots made by the given functions
+// are independent of the original list.
+func testListClone(t *testing.T, makeList func() List, clone func(List) List) {
+	list := makeList()
+	list.Add(adts.Bytes{1})
+	list.Add(adts.IntElt(2))
+
+	c := clone(list)
+	c.Get(0).(adts.Bytes)[0] = 9
+	c.Add(adts.IntElt(3))
+	if list.Len() != 2 || !list.Get(0).Equals(adts.Bytes{1}) {
+		t.Error("Changing the clone changed the original.")
+	}
+	if c.Len() != 3 || !c.Get(1).Equals(adts.IntElt(2)) {
+		t.Errorf("Clone has the wrong elements. Expected length: %d, Actual length: %d", 3, c.Len())
+	}
+
+	it := list.(adts.Snapshotter).SnapshotIterator()
+	list.Clear()
+	for idx := 0; it.Next(); idx++ {
+		if idx == 1 && !it.Value().Equals(adts.IntElt(2)) {
+			t.Errorf("SnapshotIterator saw a later change. Actual: %v", it.Value())
+		}
+	}
+}
+
+func TestSinglyLinkedListClone(t *testing.T) {
+	testListClone(t, func() List { return MakeSinglyLinkedListThreadsafe() }, func(l List) List { return l.(*SinglyLinkedList).Clone() })
+}
+
+type iterable interface {
+	Iterator() adts.MutableIterator
+}
+
+// testIterator fills c with 0 to 4, checks that its iterator visits them in
+// the given order and can remove the even ones, and that it fails fast.
+func testIterator(t *testing.T, c adts.Container, order []int) {
+	for i := 0; i < 5; i++ {
+		c.Add(adts.IntElt(i))
+	}
+
+	idx := 0
+	it := c.(iterable).Iterator()
+	for it.Next() {
+		if !it.Value().Equals(adts.IntElt(order[idx])) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", order[idx], it.Value())
+		}
+		if order[idx]%2 == 0 && (!it.Remove() || it.Remove()) {
+			t.Error("Remove should succeed once per element.")
+		}
+		idx++
+	}
+	if idx != len(order) || it.Err() != nil {
+		t.Errorf("Iterator should visit every element. Expected: %d, Actual: %d (%v)", len(order), idx, it.Err())
+	}
+	if c.Len() != 2 || !c.Contains(adts.IntElt(1)) || !c.Contains(adts.IntElt(3)) {
+		t.Errorf("Remove should remove the even elements. Expected length: %d, Actual length: %d", 2, c.Len())
+	}
+
+	it = c.(iterable).Iterator()
+	it.Next()
+	c.Add(adts.IntElt(5))
+	expectConcurrentModification(t, it)
+	if it.Remove() {
+		t.Error("Remove should fail after a concurrent modification.")
+	}
+}
+
+// expectConcurrentModification checks that Next fails fast, which panics in
+// debug builds.
+func expectConcurrentModification(t *testing.T, it adts.MutableIterator) {
+	if adts.Debug {
+		defer func() {
+			if r := recover(); r != adts.ErrConcurrentModification {
+				t.Errorf("Next should panic in debug mode. Expected: %v, Actual: %v", adts.ErrConcurrentModification, r)
+			}
+		}()
+	}
+
+	if it.Next() || it.Err() != adts.ErrConcurrentModification {
+		t.Errorf("Next should fail after a modification. Expected: %v, Actual: %v", adts.ErrConcurrentModification, it.Err())
+	}
+}
+
+func TestSinglyLinkedListIterator(t *testing.T) {
+	testIterator(t, MakeSinglyLinkedList(), []int{0, 1, 2, 3, 4})
+}
+
+func TestSinglyLinkedListThreadsafeIterator(t *testing.T) {
+	testIterator(t, MakeSinglyLinkedListThreadsafe(), []int{0, 1, 2, 3, 4})
+}
+
+func TestSinglyLinkedListConcat(t *testing.T) {
+	l := makeIntList(true, 0, 1)
+	other := makeIntList(true, 2, 3)
+
+	if !l.Concat(other) {
+		t.Fatal("Concat should succeed.")
+	}
+	checkElements(t, l, 0, 1, 2, 3)
+	checkElements(t, other)
+
+	empty := makeIntList(false)
+	empty.Concat(l)
+	empty.Add(adts.IntElt(4))
+	checkElements(t, empty, 0, 1, 2, 3, 4)
+
+	if empty.Concat(empty) {
+		t.Error("A list can't be concatenated with itself.")
+	}
+}
+
+func TestSinglyLinkedListSplitAt(t *testing.T) {
+	for _, threadSafe := range []bool{false, true} {
+		l := makeIntList(threadSafe, 0, 1, 2, 3)
+
+		rest := l.SplitAt(1)
+		checkElements(t, l, 0)
+		checkElements(t, rest, 1, 2, 3)
+
+		all := rest.SplitAt(0)
+		checkElements(t, rest)
+		checkElements(t, all, 1, 2, 3)
+
+		none := all.SplitAt(3)
+		checkElements(t, all, 1, 2, 3)
+		checkElements(t, none)
+
+		l.Add(adts.IntElt(9))
+		checkElements(t, l, 0, 9)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SplitAt past the end should panic.")
+		}
+	}()
+	makeIntList(false, 0).SplitAt(2)
+}
+
+func TestSinglyLinkedListReverse(t *testing.T) {
+	l := makeIntList(true, 0, 1, 2, 3)
+	l.Reverse()
+	checkElements(t, l, 3, 2, 1, 0)
+
+	l.Add(adts.IntElt(-1))
+	checkElements(t, l, 3, 2, 1, 0, -1)
+
+	empty := makeIntList(false)
+	empty.Reverse()
+	checkElements(t, empty)
+}
+
+func TestSinglyLinkedListRotate(t *testing.T) {
+	l := makeIntList(true, 0, 1, 2, 3)
+
+	l.Rotate(1)
+	checkElements(t, l, 1, 2, 3, 0)
+
+	l.Rotate(-1)
+	checkElements(t, l, 0, 1, 2, 3)
+
+	l.Rotate(6)
+	checkElements(t, l, 2, 3, 0, 1)
+
+	l.Rotate(4)
+	l.Add(adts.IntElt(4))
+	checkElements(t, l, 2, 3, 0, 1, 4)
+
+	empty := makeIntList(false)
+	empty.Rotate(3)
+	checkElements(t, empty)
+}
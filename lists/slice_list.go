@@ -9,14 +9,28 @@ type SliceList struct {
 	backer *adts.SliceContainer
 }
 
-// MakeSliceList creates a new non-threadsafe SliceList.
-func MakeSliceList() *SliceList {
-	return &SliceList{adts.MakeSliceContainer()}
+// NewSliceList creates a new SliceList configured by the given options.
+func NewSliceList(opts ...adts.Option) *SliceList {
+	return &SliceList{adts.NewSliceContainer(opts...)}
 }
 
-// MakeSliceListThreadSafe creates a new threadsafe SliceList.
-func MakeSliceListThreadSafe() *SliceList {
-	return &SliceList{adts.MakeSliceContainerThreadSafe()}
+// MakeSliceList creates a new non-threadsafe SliceList. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the list.
+func MakeSliceList(eq ...adts.EqualFunc) *SliceList {
+	return NewSliceList(adts.WithEquality(firstEqual(eq)))
+}
+
+// MakeSliceListThreadSafe creates a new threadsafe SliceList. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the list.
+func MakeSliceListThreadSafe(eq ...adts.EqualFunc) *SliceList {
+	return NewSliceList(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the list, which then records how
+// long it waits on its lock and when it shrinks its backing slice. It must be
+// called before the list is shared between goroutines.
+func (sl *SliceList) SetMetrics(m *adts.Metrics) {
+	sl.backer.Metrics = m
 }
 
 // -------------------------------------------------------
@@ -48,21 +62,33 @@ func (sl *SliceList) Add(item adts.ContainerElement) bool {
 	return sl.backer.Add(item)
 }
 
-// Remove removes the element at the given index and
-// returns whether the removal was successful.
+// Remove removes the first element equal to the given item and returns
+// whether an element was removed.
 func (sl *SliceList) Remove(item adts.ContainerElement) bool {
 	return sl.backer.Remove(item)
 }
 
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (sl *SliceList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	return sl.backer.RemoveElement(item)
+}
+
 // -------------------------------------------------------
 // List Methods
 // -------------------------------------------------------
 
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (sl *SliceList) IndexOf(item adts.ContainerElement) int {
+	return sl.backer.IndexOf(item)
+}
+
 // Get returns the element at the given index.
 func (sl *SliceList) Get(idx int) adts.ContainerElement {
 	if sl.backer.ThreadSafe {
-		sl.backer.Lock.Lock()
-		defer sl.backer.Lock.Unlock()
+		sl.backer.Metrics.Lock(sl.backer.Lock.RLocker())
+		defer sl.backer.Lock.RUnlock()
 		return sl.backer.Backer[idx]
 	}
 
@@ -73,7 +99,7 @@ func (sl *SliceList) Get(idx int) adts.ContainerElement {
 // and returns the old value that was at the given index.
 func (sl *SliceList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
 	if sl.backer.ThreadSafe {
-		sl.backer.Lock.Lock()
+		sl.backer.Metrics.Lock(sl.backer.Lock)
 		defer sl.backer.Lock.Unlock()
 		oldVal := sl.backer.Backer[idx]
 		sl.backer.Backer[idx] = newVal
@@ -84,3 +110,120 @@ func (sl *SliceList) Set(idx int, newVal adts.ContainerElement) adts.ContainerEl
 	sl.backer.Backer[idx] = newVal
 	return oldVal
 }
+
+// -------------------------------------------------------
+// Capacity Methods
+// -------------------------------------------------------
+
+// Cap returns the capacity of the list's backing slice.
+func (sl *SliceList) Cap() int {
+	return sl.backer.Cap()
+}
+
+// Reserve grows the backing slice, if necessary, so that n more elements can
+// be added without reallocating. It returns false if the list's
+// CapacityPolicy refuses to grow that far.
+func (sl *SliceList) Reserve(n int) bool {
+	return sl.backer.Reserve(n)
+}
+
+// TrimToSize shrinks the backing slice so its capacity equals its length.
+func (sl *SliceList) TrimToSize() {
+	sl.backer.TrimToSize()
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the list. Its Err returns
+// adts.ErrConcurrentModification if the list is changed other than through
+// the iterator's Remove.
+func (sl *SliceList) Iterator() adts.MutableIterator {
+	return sl.backer.Iterator()
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the list with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (sl *SliceList) Clone() *SliceList {
+	return &SliceList{sl.backer.Clone()}
+}
+
+// Snapshot returns a copy of the list's elements in order. The elements
+// themselves aren't cloned.
+func (sl *SliceList) Snapshot() []adts.ContainerElement {
+	return sl.backer.Snapshot()
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the list. The lock
+// (if threadsafe) is only held while the snapshot is copied.
+func (sl *SliceList) SnapshotIterator() adts.Iterator {
+	return sl.backer.SnapshotIterator()
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the list. Changes made before fn returns an error
+// are kept.
+func (sl *SliceList) Update(fn func(tx List) error) error {
+	return sl.backer.Update(func(tx *adts.SliceContainer) error {
+		return fn(&SliceList{tx})
+	})
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the list. fn must not modify the view.
+func (sl *SliceList) View(fn func(tx List) error) error {
+	return sl.backer.View(func(tx *adts.SliceContainer) error {
+		return fn(&SliceList{tx})
+	})
+}
+
+// Atomically runs fn as a single atomic operation on the list.
+func (sl *SliceList) Atomically(fn func(tx adts.Container) error) error {
+	return sl.Update(func(tx List) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent appends the given element only if it isn't already in the
+// list and returns whether it was added.
+func (sl *SliceList) AddIfAbsent(item adts.ContainerElement) bool {
+	return sl.backer.AddIfAbsent(item)
+}
+
+// CompareAndSet sets the element at the given index to newVal only if the
+// current element equals oldVal, and returns whether the element was set.
+func (sl *SliceList) CompareAndSet(idx int, oldVal, newVal adts.ContainerElement) bool {
+	swapped := false
+	sl.Update(func(tx List) error {
+		swapped = compareAndSetHelper(tx, idx, oldVal, newVal, sl.backer.Equal)
+		return nil
+	})
+
+	return swapped
+}
+
+// ReplaceAll replaces every element equal to oldVal with newVal and returns
+// the number of elements replaced.
+func (sl *SliceList) ReplaceAll(oldVal, newVal adts.ContainerElement) int {
+	replaced := 0
+	sl.backer.Update(func(tx *adts.SliceContainer) error {
+		for idx, val := range tx.Backer {
+			if tx.Equal.Equal(val, oldVal) {
+				tx.Backer[idx] = newVal
+				replaced++
+			}
+		}
+		return nil
+	})
+
+	return replaced
+}
@@ -197,3 +197,101 @@ func TestSliceListSet(t *testing.T) {
 		}
 	}
 }
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestSliceListCompareAndSet(t *testing.T) {
+	var a adts.Atomic
+	a = MakeSliceListThreadSafe()
+
+	list := a.(*SliceList)
+	for i := 0; i < 10; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	if list.CompareAndSet(3, adts.IntElt(4), adts.IntElt(30)) {
+		t.Error("CompareAndSet should fail when the current value doesn't match.")
+	}
+	if !list.CompareAndSet(3, adts.IntElt(3), adts.IntElt(30)) {
+		t.Error("CompareAndSet should succeed when the current value matches.")
+	}
+	if !list.Get(3).Equals(adts.IntElt(30)) {
+		t.Errorf("CompareAndSet didn't set the value. Expected: %v, Actual: %v", 30, list.Get(3))
+	}
+}
+
+func TestSliceListReplaceAll(t *testing.T) {
+	list := MakeSliceList()
+	for i := 0; i < 10; i++ {
+		list.Add(adts.IntElt(i % 3))
+	}
+
+	if replaced := list.ReplaceAll(adts.IntElt(1), adts.IntElt(7)); replaced != 3 {
+		t.Errorf("Expected 3 replacements, Actual: %d", replaced)
+	}
+	if list.Contains(adts.IntElt(1)) || !list.Get(1).Equals(adts.IntElt(7)) {
+		t.Error("ReplaceAll didn't replace every matching element.")
+	}
+	if !list.AddIfAbsent(adts.IntElt(5)) || list.AddIfAbsent(adts.IntElt(5)) {
+		t.Error("AddIfAbsent should only add an element once.")
+	}
+}
+
+func TestSliceListEqualFunc(t *testing.T) {
+	list := MakeSliceList(lastDigit)
+	for i := 0; i < 5; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	if idx := list.IndexOf(adts.IntElt(13)); idx != 3 {
+		t.Errorf("IndexOf should use the list's EqualFunc. Expected: %d, Actual: %d", 3, idx)
+	}
+	if list.IndexOf(adts.IntElt(9)) != -1 {
+		t.Error("IndexOf should return -1 for missing elements.")
+	}
+	if !list.Contains(adts.IntElt(14)) || !list.Remove(adts.IntElt(10)) || list.Len() != 4 {
+		t.Error("Contains and Remove should use the list's EqualFunc.")
+	}
+	if !list.CompareAndSet(0, adts.IntElt(21), adts.IntElt(7)) {
+		t.Error("CompareAndSet should use the list's EqualFunc.")
+	}
+	if list.ReplaceAll(adts.IntElt(17), adts.IntElt(8)) != 1 {
+		t.Error("ReplaceAll should use the list's EqualFunc.")
+	}
+}
+
+func TestSliceListCapacity(t *testing.T) {
+	c := NewSliceList(adts.WithCapacityPolicy(adts.NeverShrink{}))
+
+	if !c.Reserve(50) || c.Cap() < 50 {
+		t.Errorf("Reserve didn't make room. Expected at least: %d, Actual: %d", 50, c.Cap())
+	}
+	for i := 0; i < 10; i++ {
+		c.Add(adts.IntElt(i))
+	}
+	for i := 0; i < 10; i++ {
+		c.Remove(adts.IntElt(i))
+	}
+	if c.Cap() < 50 {
+		t.Errorf("NeverShrink shouldn't shrink. Expected at least: %d, Actual: %d", 50, c.Cap())
+	}
+
+	c.TrimToSize()
+	if c.Cap() != 0 {
+		t.Errorf("TrimToSize should release the capacity. Expected: %d, Actual: %d", 0, c.Cap())
+	}
+}
+
+func TestSliceListClone(t *testing.T) {
+	testListClone(t, func() List { return MakeSliceListThreadSafe() }, func(l List) List { return l.(*SliceList).Clone() })
+}
+
+func TestSliceListIterator(t *testing.T) {
+	testIterator(t, MakeSliceList(), []int{0, 1, 2, 3, 4})
+}
+
+func TestSliceListThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeSliceListThreadSafe(), []int{0, 1, 2, 3, 4})
+}
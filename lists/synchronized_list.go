@@ -0,0 +1,162 @@
+package listadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// SyncList wraps any List so that every method call is guarded by a single RWMutex.
+// The wrapped list should not be used directly once it has been wrapped.
+type SyncList struct {
+	inner List
+	lock  *sync.RWMutex
+}
+
+// SynchronizedList wraps the given list so that it is safe for concurrent use.
+func SynchronizedList(l List) *SyncList {
+	return &SyncList{l, &sync.RWMutex{}}
+}
+
+// WithLock runs fn while holding the write lock, passing it the wrapped
+// list. This allows compound operations to run without another goroutine
+// seeing the intermediate state. fn must not call back into the SyncList,
+// since that would deadlock.
+func (s *SyncList) WithLock(fn func(List)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(s.inner)
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (s *SyncList) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Len()
+}
+
+// IsEmpty returns if the list is empty or not.
+func (s *SyncList) IsEmpty() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.IsEmpty()
+}
+
+// Clear removes all elements from the list.
+func (s *SyncList) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.inner.Clear()
+}
+
+// Contains returns true if the given item is in the list.
+func (s *SyncList) Contains(item adts.ContainerElement) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the list.
+func (s *SyncList) Add(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Add(item)
+}
+
+// Remove returns true if the given element was removed.
+func (s *SyncList) Remove(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (s *SyncList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return adts.RemoveElement(s.inner, item)
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// Get returns the element at the given index.
+func (s *SyncList) Get(idx int) adts.ContainerElement {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Get(idx)
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (s *SyncList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Set(idx, newVal)
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock, passing it the wrapped
+// list. Changes made before fn returns an error are kept.
+func (s *SyncList) Update(fn func(tx List) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// View runs fn while holding the read lock, passing it the wrapped list.
+// fn must not modify the list.
+func (s *SyncList) View(fn func(tx List) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return fn(s.inner)
+}
+
+// Atomically runs fn as a single atomic operation on the wrapped list.
+func (s *SyncList) Atomically(fn func(tx adts.Container) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// AddIfAbsent adds the given element only if it isn't already in the
+// list and returns whether it was added.
+func (s *SyncList) AddIfAbsent(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.inner.Contains(item) && s.inner.Add(item)
+}
+
+// CompareAndSet sets the element at the given index to newVal only if the
+// current element equals oldVal, and returns whether the element was set.
+func (s *SyncList) CompareAndSet(idx int, oldVal, newVal adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return compareAndSetHelper(s.inner, idx, oldVal, newVal, nil)
+}
+
+// ReplaceAll replaces every element equal to oldVal with newVal and returns
+// the number of elements replaced.
+func (s *SyncList) ReplaceAll(oldVal, newVal adts.ContainerElement) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	replaced := 0
+	for idx := 0; idx < s.inner.Len(); idx++ {
+		if s.inner.Get(idx).Equals(oldVal) {
+			s.inner.Set(idx, newVal)
+			replaced++
+		}
+	}
+
+	return replaced
+}
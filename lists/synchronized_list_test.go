@@ -0,0 +1,60 @@
+package listadts
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestSynchronizedList(t *testing.T) {
+	var l List
+	l = SynchronizedList(MakeSinglyLinkedList())
+
+	for i := 0; i < 100; i++ {
+		l.Add(adts.IntElt(i))
+	}
+
+	if l.Len() != 100 {
+		t.Errorf("Expected length: %d, Actual length: %d", 100, l.Len())
+	}
+
+	for i := 0; i < 100; i++ {
+		old := l.Set(i, adts.IntElt(i*2))
+		if !old.Equals(adts.IntElt(i)) {
+			t.Errorf("Set returned the wrong old value. Expected: %v, Actual: %v", i, old)
+			return
+		}
+		if !l.Get(i).Equals(adts.IntElt(i * 2)) {
+			t.Errorf("Set did not set element at index %d properly. Expected: %v, Actual: %v", i, i*2, l.Get(i))
+			return
+		}
+	}
+}
+
+func TestSynchronizedListConcurrent(t *testing.T) {
+	l := SynchronizedList(MakeSliceList())
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.Add(adts.IntElt(i))
+				l.Contains(adts.IntElt(i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if l.Len() != 800 {
+		t.Errorf("Expected length: %d, Actual length: %d", 800, l.Len())
+	}
+
+	// Swap the first and last elements as one compound operation.
+	l.WithLock(func(inner List) {
+		last := inner.Len() - 1
+		inner.Set(last, inner.Set(0, inner.Get(last)))
+	})
+}
@@ -0,0 +1,668 @@
+package listadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// DefaultNodeCapacity is the number of elements each node of an UnrolledList
+// holds unless adts.WithNodeCapacity says otherwise.
+const DefaultNodeCapacity = 64
+
+// unrolledNode is a node of an UnrolledList. Nodes in a list are never empty.
+type unrolledNode struct {
+	elts []adts.ContainerElement
+	next *unrolledNode
+}
+
+// UnrolledList is a linked list whose nodes each hold up to a fixed number
+// of elements in a small slice. It allocates one node per NodeCapacity
+// elements instead of one per element, and walking it mostly reads
+// contiguous memory. Nodes are split when an insertion fills them and merged
+// with their neighbour when removals leave them less than half full.
+type UnrolledList struct {
+	head       *unrolledNode
+	tail       *unrolledNode
+	len        int
+	nodeCap    int
+	lock       *sync.RWMutex
+	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+	modCount   uint64
+}
+
+// NewUnrolledList creates a new UnrolledList configured by the given options.
+// NodeCapacity sets the number of elements per node (at least 2). Capacity
+// and ShrinkFactor don't apply and are ignored.
+func NewUnrolledList(opts ...adts.Option) *UnrolledList {
+	o := adts.MakeOptions(opts...)
+
+	nodeCap := o.NodeCapacity
+	if nodeCap == 0 {
+		nodeCap = DefaultNodeCapacity
+	} else if nodeCap < 2 {
+		nodeCap = 2
+	}
+
+	return &UnrolledList{nil, nil, 0, nodeCap, &sync.RWMutex{}, o.ThreadSafe, o.Metrics, o.Equal, 0}
+}
+
+// MakeUnrolledList creates a new non-threadsafe UnrolledList. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the list.
+func MakeUnrolledList(eq ...adts.EqualFunc) *UnrolledList {
+	return NewUnrolledList(adts.WithEquality(firstEqual(eq)))
+}
+
+// MakeUnrolledListThreadSafe creates a new threadsafe UnrolledList. An
+// optional EqualFunc replaces ContainerElement.Equals when searching the list.
+func MakeUnrolledListThreadSafe(eq ...adts.EqualFunc) *UnrolledList {
+	return NewUnrolledList(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the list, which then records how
+// long it waits on its lock. It must be called before the list is shared
+// between goroutines.
+func (l *UnrolledList) SetMetrics(m *adts.Metrics) {
+	l.metrics = m
+}
+
+// newNode returns an empty node with room for a full node of elements.
+func (l *UnrolledList) newNode() *unrolledNode {
+	return &unrolledNode{make([]adts.ContainerElement, 0, l.nodeCap), nil}
+}
+
+// find returns the node before the one holding idx, that node, and idx's
+// offset in it. idx must be in range.
+func (l *UnrolledList) find(idx int) (*unrolledNode, *unrolledNode, int) {
+	var prev *unrolledNode
+	node := l.head
+	for idx >= len(node.elts) {
+		idx -= len(node.elts)
+		prev, node = node, node.next
+	}
+
+	return prev, node, idx
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the list.
+func (l *UnrolledList) Len() int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.len
+	}
+
+	return l.len
+}
+
+// IsEmpty returns if the list is empty or not.
+func (l *UnrolledList) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+// Clear removes all elements from the list.
+func (l *UnrolledList) Clear() {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		l.clearHelper()
+		return
+	}
+
+	l.clearHelper()
+}
+
+// clearHelper drops every node from the list.
+func (l *UnrolledList) clearHelper() {
+	l.head = nil
+	l.tail = nil
+	l.len = 0
+	l.modCount++
+}
+
+// Contains returns true if the given item is in the list.
+func (l *UnrolledList) Contains(item adts.ContainerElement) bool {
+	return l.IndexOf(item) >= 0
+}
+
+// Add returns true if the given element was appended to the end of the list.
+func (l *UnrolledList) Add(item adts.ContainerElement) bool {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.addHelper(item)
+	}
+
+	return l.addHelper(item)
+}
+
+// addHelper appends the element to the tail node, starting a new node when
+// the tail is full. Appends leave every node but the tail full.
+func (l *UnrolledList) addHelper(item adts.ContainerElement) bool {
+	if l.tail == nil {
+		l.head = l.newNode()
+		l.tail = l.head
+	} else if len(l.tail.elts) == l.nodeCap {
+		l.tail.next = l.newNode()
+		l.tail = l.tail.next
+	}
+
+	l.tail.elts = append(l.tail.elts, item)
+	l.len++
+	l.modCount++
+	return true
+}
+
+// Remove removes the first element equal to the given item and returns
+// whether an element was removed.
+func (l *UnrolledList) Remove(item adts.ContainerElement) bool {
+	_, ok := l.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (l *UnrolledList) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.removeHelper(item)
+	}
+
+	return l.removeHelper(item)
+}
+
+// removeHelper searches the nodes for the element and removes it.
+func (l *UnrolledList) removeHelper(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	var prev *unrolledNode
+	for node := l.head; node != nil; prev, node = node, node.next {
+		for off, elt := range node.elts {
+			if l.equal.Equal(elt, item) {
+				l.removeFromNode(prev, node, off)
+				return elt, true
+			}
+		}
+	}
+
+	return adts.EmptyContainerElement{}, false
+}
+
+// removeFromNode removes the element at off in node, then merges node with
+// the next one or borrows from it if node is less than half full, and
+// unlinks node if it's left empty.
+func (l *UnrolledList) removeFromNode(prev, node *unrolledNode, off int) adts.ContainerElement {
+	removed := node.elts[off]
+	n := len(node.elts)
+	copy(node.elts[off:], node.elts[off+1:])
+	node.elts[n-1] = nil
+	node.elts = node.elts[:n-1]
+
+	l.len--
+	l.modCount++
+
+	if next := node.next; next != nil && len(node.elts) < l.nodeCap/2 {
+		if len(node.elts)+len(next.elts) <= l.nodeCap {
+			// Both fit in one node, so take all of next's elements.
+			node.elts = append(node.elts, next.elts...)
+			node.next = next.next
+			if next == l.tail {
+				l.tail = node
+			}
+		} else {
+			// Even the two nodes out by moving the front of next over.
+			k := (len(next.elts) - len(node.elts)) / 2
+			node.elts = append(node.elts, next.elts[:k]...)
+			m := copy(next.elts, next.elts[k:])
+			clear(next.elts[m:])
+			next.elts = next.elts[:m]
+		}
+	}
+
+	if len(node.elts) == 0 {
+		if prev == nil {
+			l.head = node.next
+		} else {
+			prev.next = node.next
+		}
+		if node == l.tail {
+			l.tail = prev
+		}
+	}
+
+	return removed
+}
+
+// -------------------------------------------------------
+// List Methods
+// -------------------------------------------------------
+
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (l *UnrolledList) IndexOf(item adts.ContainerElement) int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.indexOfHelper(item)
+	}
+
+	return l.indexOfHelper(item)
+}
+
+// indexOfHelper walks the nodes looking for the given element.
+func (l *UnrolledList) indexOfHelper(item adts.ContainerElement) int {
+	base := 0
+	for node := l.head; node != nil; node = node.next {
+		for off, elt := range node.elts {
+			if l.equal.Equal(elt, item) {
+				return base + off
+			}
+		}
+		base += len(node.elts)
+	}
+
+	return -1
+}
+
+// Get returns the element at the given index.
+func (l *UnrolledList) Get(idx int) adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.getHelper(idx)
+	}
+
+	return l.getHelper(idx)
+}
+
+// getHelper finds the node holding idx and returns the element.
+func (l *UnrolledList) getHelper(idx int) adts.ContainerElement {
+	if idx < 0 || idx >= l.len {
+		panic("index out of range")
+	}
+
+	_, node, off := l.find(idx)
+	return node.elts[off]
+}
+
+// Set changes the value at the given index to the given new value
+// and returns the old value that was at the given index.
+func (l *UnrolledList) Set(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.setHelper(idx, newVal)
+	}
+
+	return l.setHelper(idx, newVal)
+}
+
+// setHelper finds the node holding idx and replaces the element.
+func (l *UnrolledList) setHelper(idx int, newVal adts.ContainerElement) adts.ContainerElement {
+	if idx < 0 || idx >= l.len {
+		panic("index out of range")
+	}
+
+	_, node, off := l.find(idx)
+	oldVal := node.elts[off]
+	node.elts[off] = newVal
+	return oldVal
+}
+
+// InsertAt inserts the given element at the given index, shifting the
+// elements after it back. An index equal to Len appends.
+func (l *UnrolledList) InsertAt(idx int, item adts.ContainerElement) {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		l.insertAtHelper(idx, item)
+		return
+	}
+
+	l.insertAtHelper(idx, item)
+}
+
+// insertAtHelper inserts into the node holding idx, first splitting it in
+// half if it's full.
+func (l *UnrolledList) insertAtHelper(idx int, item adts.ContainerElement) {
+	if idx < 0 || idx > l.len {
+		panic("index out of range")
+	}
+	if idx == l.len {
+		l.addHelper(item)
+		return
+	}
+
+	_, node, off := l.find(idx)
+	if len(node.elts) == l.nodeCap {
+		half := l.nodeCap / 2
+		newNode := l.newNode()
+		newNode.elts = append(newNode.elts, node.elts[half:]...)
+		clear(node.elts[half:])
+		node.elts = node.elts[:half]
+
+		newNode.next = node.next
+		node.next = newNode
+		if node == l.tail {
+			l.tail = newNode
+		}
+
+		if off > half {
+			node, off = newNode, off-half
+		}
+	}
+
+	node.elts = append(node.elts, nil)
+	copy(node.elts[off+1:], node.elts[off:])
+	node.elts[off] = item
+
+	l.len++
+	l.modCount++
+}
+
+// RemoveAt removes the element at the given index and returns it.
+func (l *UnrolledList) RemoveAt(idx int) adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.removeAtHelper(idx)
+	}
+
+	return l.removeAtHelper(idx)
+}
+
+// removeAtHelper finds the node holding idx and removes the element.
+func (l *UnrolledList) removeAtHelper(idx int) adts.ContainerElement {
+	if idx < 0 || idx >= l.len {
+		panic("index out of range")
+	}
+
+	prev, node, off := l.find(idx)
+	return l.removeFromNode(prev, node, off)
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the list. Its Err returns
+// adts.ErrConcurrentModification if the list is changed other than through
+// the iterator's Remove.
+func (l *UnrolledList) Iterator() adts.MutableIterator {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+	}
+
+	return &unrolledListIterator{l: l, idx: -1, expected: l.modCount}
+}
+
+// unrolledListIterator is the fail-fast iterator of an UnrolledList. Remove
+// can merge or unlink nodes, so after a removal the iterator finds its place
+// again by index.
+type unrolledListIterator struct {
+	l         *UnrolledList
+	node      *unrolledNode
+	off       int
+	idx       int
+	cur       adts.ContainerElement
+	expected  uint64
+	removable bool
+	removed   bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the list has been changed.
+func (it *unrolledListIterator) Next() bool {
+	if it.l.threadSafe {
+		it.l.metrics.Lock(it.l.lock.RLocker())
+		defer it.l.lock.RUnlock()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.l.modCount); it.err != nil {
+		return false
+	}
+
+	switch {
+	case it.removed:
+		// The next element has moved into the removed one's index.
+		it.removed = false
+		if it.idx < it.l.len {
+			_, it.node, it.off = it.l.find(it.idx)
+		} else {
+			it.node = nil
+		}
+	case it.idx < 0:
+		it.idx, it.node, it.off = 0, it.l.head, 0
+	case it.node != nil:
+		it.idx++
+		if it.off++; it.off == len(it.node.elts) {
+			it.node, it.off = it.node.next, 0
+		}
+	}
+
+	if it.node == nil {
+		it.cur = nil
+		return false
+	}
+
+	it.cur = it.node.elts[it.off]
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *unrolledListIterator) Value() adts.ContainerElement {
+	if it.cur == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.cur
+}
+
+// Err returns adts.ErrConcurrentModification if the list was changed during
+// iteration.
+func (it *unrolledListIterator) Err() error {
+	return it.err
+}
+
+// Remove removes the current element from the list.
+func (it *unrolledListIterator) Remove() bool {
+	if it.l.threadSafe {
+		it.l.metrics.Lock(it.l.lock)
+		defer it.l.lock.Unlock()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.l.modCount); it.err != nil {
+		return false
+	}
+
+	it.l.removeAtHelper(it.idx)
+	it.expected = it.l.modCount
+	it.removable = false
+	it.removed = true
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the list with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (l *UnrolledList) Clone() *UnrolledList {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.cloneHelper()
+	}
+
+	return l.cloneHelper()
+}
+
+// cloneHelper copies the list node by node without locking.
+func (l *UnrolledList) cloneHelper() *UnrolledList {
+	clone := &UnrolledList{nil, nil, l.len, l.nodeCap, &sync.RWMutex{}, l.threadSafe, l.metrics, l.equal, 0}
+	for node := l.head; node != nil; node = node.next {
+		copied := l.newNode()
+		copied.elts = append(copied.elts, adts.CloneElements(node.elts)...)
+		if clone.tail == nil {
+			clone.head = copied
+		} else {
+			clone.tail.next = copied
+		}
+		clone.tail = copied
+	}
+
+	return clone
+}
+
+// Snapshot returns a copy of the list's elements in order. The elements
+// themselves aren't cloned.
+func (l *UnrolledList) Snapshot() []adts.ContainerElement {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return l.snapshotHelper()
+	}
+
+	return l.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (l *UnrolledList) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, l.len)
+	for node := l.head; node != nil; node = node.next {
+		elts = append(elts, node.elts...)
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the list. The lock
+// (if threadsafe) is only held while the snapshot is copied.
+func (l *UnrolledList) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(l.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the list that shares its nodes.
+// Any changes made through the view must be copied back with commit.
+func (l *UnrolledList) unlocked() *UnrolledList {
+	return &UnrolledList{l.head, l.tail, l.len, l.nodeCap, l.lock, false, l.metrics, l.equal, l.modCount}
+}
+
+// commit copies the state of the given view back into the list.
+func (l *UnrolledList) commit(view *UnrolledList) {
+	l.head = view.head
+	l.tail = view.tail
+	l.len = view.len
+	l.modCount = view.modCount
+}
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the list. Changes made before fn returns an error
+// are kept.
+func (l *UnrolledList) Update(fn func(tx List) error) error {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.updateHelper(fn)
+	}
+
+	return l.updateHelper(fn)
+}
+
+// updateHelper runs fn against a view of the list and then copies the
+// view's state back into the list.
+func (l *UnrolledList) updateHelper(fn func(tx List) error) error {
+	view := l.unlocked()
+	defer l.commit(view)
+	return fn(view)
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the list. fn must not modify the view.
+func (l *UnrolledList) View(fn func(tx List) error) error {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock.RLocker())
+		defer l.lock.RUnlock()
+		return fn(l.unlocked())
+	}
+
+	return fn(l.unlocked())
+}
+
+// Atomically runs fn as a single atomic operation on the list.
+func (l *UnrolledList) Atomically(fn func(tx adts.Container) error) error {
+	return l.Update(func(tx List) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent appends the given element only if it isn't already in the
+// list and returns whether it was added.
+func (l *UnrolledList) AddIfAbsent(item adts.ContainerElement) bool {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.indexOfHelper(item) < 0 && l.addHelper(item)
+	}
+
+	return l.indexOfHelper(item) < 0 && l.addHelper(item)
+}
+
+// CompareAndSet sets the element at the given index to newVal only if the
+// current element equals oldVal, and returns whether the element was set.
+func (l *UnrolledList) CompareAndSet(idx int, oldVal, newVal adts.ContainerElement) bool {
+	swapped := false
+	l.Update(func(tx List) error {
+		swapped = compareAndSetHelper(tx, idx, oldVal, newVal, l.equal)
+		return nil
+	})
+
+	return swapped
+}
+
+// ReplaceAll replaces every element equal to oldVal with newVal and returns
+// the number of elements replaced.
+func (l *UnrolledList) ReplaceAll(oldVal, newVal adts.ContainerElement) int {
+	if l.threadSafe {
+		l.metrics.Lock(l.lock)
+		defer l.lock.Unlock()
+		return l.replaceAllHelper(oldVal, newVal)
+	}
+
+	return l.replaceAllHelper(oldVal, newVal)
+}
+
+// replaceAllHelper walks the nodes once, replacing every matching element.
+func (l *UnrolledList) replaceAllHelper(oldVal, newVal adts.ContainerElement) int {
+	replaced := 0
+	for node := l.head; node != nil; node = node.next {
+		for off, elt := range node.elts {
+			if l.equal.Equal(elt, oldVal) {
+				node.elts[off] = newVal
+				replaced++
+			}
+		}
+	}
+
+	return replaced
+}
@@ -0,0 +1,274 @@
+package listadts
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// checkUnrolledList checks the list against the expected elements and that
+// every node is non-empty and within capacity, and the tail is the last node.
+func checkUnrolledList(t *testing.T, l *UnrolledList, expected []adts.ContainerElement) {
+	t.Helper()
+
+	count := 0
+	var last *unrolledNode
+	for node := l.head; node != nil; node = node.next {
+		if len(node.elts) == 0 || len(node.elts) > l.nodeCap {
+			t.Fatalf("Bad node size. Expected: 1 to %d, Actual: %d", l.nodeCap, len(node.elts))
+		}
+		count += len(node.elts)
+		last = node
+	}
+	if last != l.tail {
+		t.Fatal("The tail should be the last node.")
+	}
+	if count != len(expected) || l.Len() != len(expected) {
+		t.Fatalf("Wrong length. Expected: %d, Actual: %d (Len %d)", len(expected), count, l.Len())
+	}
+	for i, elt := range l.Snapshot() {
+		if !elt.Equals(expected[i]) {
+			t.Fatalf("Wrong element at %d. Expected: %v, Actual: %v", i, expected[i], elt)
+		}
+	}
+}
+
+func TestMakeUnrolledList(t *testing.T) {
+	list := MakeUnrolledList()
+	if list.nodeCap != DefaultNodeCapacity || list.threadSafe || !list.IsEmpty() {
+		t.Errorf("Unexpected new list. Actual: %+v", list)
+	}
+
+	if l := NewUnrolledList(adts.WithNodeCapacity(1)); l.nodeCap != 2 {
+		t.Errorf("Node capacity should be at least 2. Expected: %d, Actual: %d", 2, l.nodeCap)
+	}
+	if l := MakeUnrolledListThreadSafe(); !l.threadSafe {
+		t.Error("MakeUnrolledListThreadSafe should make a threadsafe list.")
+	}
+}
+
+func TestUnrolledListAddGetSet(t *testing.T) {
+	list := NewUnrolledList(adts.WithNodeCapacity(4))
+	var expected []adts.ContainerElement
+	for i := 0; i < 10; i++ {
+		list.Add(adts.IntElt(i))
+		expected = append(expected, adts.IntElt(i))
+	}
+	checkUnrolledList(t, list, expected)
+
+	for i := 0; i < 10; i++ {
+		if !list.Get(i).Equals(adts.IntElt(i)) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", i, list.Get(i))
+		}
+	}
+	if old := list.Set(5, adts.IntElt(50)); !old.Equals(adts.IntElt(5)) || list.IndexOf(adts.IntElt(50)) != 5 {
+		t.Errorf("Set should replace the element. Expected: %d, Actual: %v", 5, old)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Get out of range should panic.")
+		}
+	}()
+	list.Get(10)
+}
+
+func TestUnrolledListInsertAtSplits(t *testing.T) {
+	list := NewUnrolledList(adts.WithNodeCapacity(4))
+	for i := 0; i < 4; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	list.InsertAt(1, adts.IntElt(9))
+	if list.head.next == nil || len(list.head.elts) != 3 || len(list.head.next.elts) != 2 {
+		t.Errorf("Inserting into a full node should split it. Actual sizes: %d, %v", len(list.head.elts), list.head.next)
+	}
+	checkUnrolledList(t, list, []adts.ContainerElement{adts.IntElt(0), adts.IntElt(9), adts.IntElt(1), adts.IntElt(2), adts.IntElt(3)})
+}
+
+func TestUnrolledListRemoveMerges(t *testing.T) {
+	list := NewUnrolledList(adts.WithNodeCapacity(4))
+	for i := 0; i < 8; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	// The head drops below half full, but the two nodes don't fit in one, so
+	// it borrows from the next node.
+	list.RemoveAt(0)
+	list.RemoveAt(0)
+	list.RemoveAt(0)
+	if len(list.head.elts) != 2 || len(list.tail.elts) != 3 {
+		t.Errorf("The head should borrow from the next node. Expected: %d, Actual: %d", 2, len(list.head.elts))
+	}
+
+	list.RemoveAt(0)
+	if list.head != list.tail {
+		t.Error("Removing from a half empty node should merge it with the next one.")
+	}
+	checkUnrolledList(t, list, []adts.ContainerElement{adts.IntElt(4), adts.IntElt(5), adts.IntElt(6), adts.IntElt(7)})
+
+	if !list.Remove(adts.IntElt(7)) || list.Remove(adts.IntElt(7)) {
+		t.Error("Remove should remove an element once.")
+	}
+	list.Add(adts.IntElt(8))
+	checkUnrolledList(t, list, []adts.ContainerElement{adts.IntElt(4), adts.IntElt(5), adts.IntElt(6), adts.IntElt(8)})
+}
+
+func TestUnrolledListRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, nodeCap := range []int{2, 3, 4, 7, 16} {
+		t.Run(fmt.Sprintf("NodeCapacity%d", nodeCap), func(t *testing.T) {
+			list := NewUnrolledList(adts.WithNodeCapacity(nodeCap))
+			var model []adts.ContainerElement
+
+			for op := 0; op < 2000; op++ {
+				elt := adts.IntElt(r.Intn(50))
+				switch r.Intn(5) {
+				case 0:
+					list.Add(elt)
+					model = append(model, elt)
+				case 1:
+					idx := r.Intn(len(model) + 1)
+					list.InsertAt(idx, elt)
+					model = append(model[:idx], append([]adts.ContainerElement{elt}, model[idx:]...)...)
+				case 2:
+					if len(model) > 0 {
+						idx := r.Intn(len(model))
+						list.RemoveAt(idx)
+						model = append(model[:idx], model[idx+1:]...)
+					}
+				case 3:
+					found := false
+					for i, m := range model {
+						if m.Equals(elt) {
+							model = append(model[:i], model[i+1:]...)
+							found = true
+							break
+						}
+					}
+					if list.Remove(elt) != found {
+						t.Fatalf("Remove returned the wrong result for %v. Expected: %t", elt, found)
+					}
+				case 4:
+					if len(model) > 0 {
+						idx := r.Intn(len(model))
+						list.Set(idx, elt)
+						model[idx] = elt
+					}
+				}
+				checkUnrolledList(t, list, model)
+			}
+		})
+	}
+}
+
+func TestUnrolledListUpdate(t *testing.T) {
+	list := NewUnrolledList(adts.WithThreadSafety(), adts.WithNodeCapacity(2))
+	list.Update(func(tx List) error {
+		for i := 0; i < 5; i++ {
+			tx.Add(adts.IntElt(i))
+		}
+		tx.Remove(adts.IntElt(0))
+		return nil
+	})
+	checkUnrolledList(t, list, []adts.ContainerElement{adts.IntElt(1), adts.IntElt(2), adts.IntElt(3), adts.IntElt(4)})
+
+	if !list.CompareAndSet(0, adts.IntElt(1), adts.IntElt(7)) || list.ReplaceAll(adts.IntElt(7), adts.IntElt(8)) != 1 {
+		t.Error("CompareAndSet and ReplaceAll should change the first element.")
+	}
+	if list.AddIfAbsent(adts.IntElt(8)) || !list.AddIfAbsent(adts.IntElt(9)) {
+		t.Error("AddIfAbsent should only add missing elements.")
+	}
+}
+
+func TestUnrolledListClone(t *testing.T) {
+	testListClone(t, func() List { return MakeUnrolledListThreadSafe() }, func(l List) List { return l.(*UnrolledList).Clone() })
+}
+
+func TestUnrolledListIterator(t *testing.T) {
+	testIterator(t, NewUnrolledList(adts.WithNodeCapacity(2)), []int{0, 1, 2, 3, 4})
+}
+
+func TestUnrolledListThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeUnrolledListThreadSafe(), []int{0, 1, 2, 3, 4})
+}
+
+// -------------------------------------------------------
+// Benchmarks
+// -------------------------------------------------------
+
+// benchLists are the lists the benchmarks compare.
+var benchLists = []struct {
+	name string
+	make func() List
+}{
+	{"SliceList", func() List { return MakeSliceList() }},
+	{"SinglyLinkedList", func() List { return MakeSinglyLinkedList() }},
+	{"UnrolledList", func() List { return MakeUnrolledList() }},
+	{"ArenaList", func() List { return MakeArenaList() }},
+}
+
+// filledList returns a list of the given kind holding 0 to n-1.
+func filledList(makeList func() List, n int) List {
+	list := makeList()
+	for i := 0; i < n; i++ {
+		list.Add(adts.IntElt(i))
+	}
+
+	return list
+}
+
+func BenchmarkListAdd(b *testing.B) {
+	for _, bl := range benchLists {
+		b.Run(bl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				filledList(bl.make, 1024)
+			}
+		})
+	}
+}
+
+func BenchmarkListGet(b *testing.B) {
+	for _, bl := range benchLists {
+		b.Run(bl.name, func(b *testing.B) {
+			list := filledList(bl.make, 1024)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				list.Get(i & 1023)
+			}
+		})
+	}
+}
+
+func BenchmarkListIterate(b *testing.B) {
+	for _, bl := range benchLists {
+		b.Run(bl.name, func(b *testing.B) {
+			list := filledList(bl.make, 1024)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for it := list.(iterable).Iterator(); it.Next(); {
+					it.Value()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkListRemove(b *testing.B) {
+	for _, bl := range benchLists {
+		b.Run(bl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				list := filledList(bl.make, 1024)
+				b.StartTimer()
+				for j := 0; j < 1024; j += 2 {
+					list.Remove(adts.IntElt(j))
+				}
+			}
+		})
+	}
+}
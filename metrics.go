@@ -0,0 +1,168 @@
+package adts
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Op identifies a container operation tracked by Metrics.
+type Op int
+
+// The operations tracked by Metrics.
+const (
+	OpLen Op = iota
+	OpIsEmpty
+	OpClear
+	OpContains
+	OpAdd
+	OpRemove
+	OpGet
+	OpSet
+	OpPush
+	OpPop
+	OpEnqueue
+	OpDequeue
+	numOps
+)
+
+var opNames = [...]string{"Len", "IsEmpty", "Clear", "Contains", "Add", "Remove", "Get", "Set", "Push", "Pop", "Enqueue", "Dequeue"}
+
+// String returns the name of the operation.
+func (o Op) String() string {
+	if o < 0 || o >= numOps {
+		return "Unknown"
+	}
+
+	return opNames[o]
+}
+
+// Metrics collects statistics about the containers it's attached to. It's
+// safe for concurrent use, and a nil *Metrics is valid and records nothing,
+// so containers can call it unconditionally.
+//
+// Operation counts, latencies and the Len high-water mark are recorded by
+// the instrumented wrappers (Instrument, listadts.InstrumentList, etc.). Lock
+// waits and shrinks are recorded by the threadsafe containers themselves once
+// a Metrics is attached to them.
+type Metrics struct {
+	counts    [numOps]atomic.Int64
+	latencies [numOps]atomic.Int64
+	highWater atomic.Int64
+	lockWaits atomic.Int64
+	lockWait  atomic.Int64
+	shrinks   atomic.Int64
+}
+
+// MakeMetrics creates a new Metrics with every statistic at zero.
+func MakeMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Lock acquires the given lock, recording how long it took to acquire.
+// Use l.RLocker() to acquire the read lock of a sync.RWMutex.
+func (m *Metrics) Lock(l sync.Locker) {
+	if m == nil {
+		l.Lock()
+		return
+	}
+
+	start := time.Now()
+	l.Lock()
+	m.lockWaits.Add(1)
+	m.lockWait.Add(int64(time.Since(start)))
+}
+
+// ObserveOp records one call of the given operation that started at start.
+func (m *Metrics) ObserveOp(op Op, start time.Time) {
+	if m == nil || op < 0 || op >= numOps {
+		return
+	}
+
+	m.counts[op].Add(1)
+	m.latencies[op].Add(int64(time.Since(start)))
+}
+
+// ObserveLen records the current length of a container, raising the
+// high-water mark if needed.
+func (m *Metrics) ObserveLen(n int) {
+	if m == nil {
+		return
+	}
+
+	for {
+		cur := m.highWater.Load()
+		if int64(n) <= cur || m.highWater.CompareAndSwap(cur, int64(n)) {
+			return
+		}
+	}
+}
+
+// ObserveShrink records that a container shrank its backing storage.
+func (m *Metrics) ObserveShrink() {
+	if m == nil {
+		return
+	}
+
+	m.shrinks.Add(1)
+}
+
+// OpStats holds the statistics for a single operation.
+type OpStats struct {
+	Count        int64
+	TotalLatency time.Duration
+}
+
+// MeanLatency returns the average time a single call took.
+func (s OpStats) MeanLatency() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.TotalLatency / time.Duration(s.Count)
+}
+
+// Stats is a point in time copy of the statistics collected by a Metrics.
+type Stats struct {
+	// Ops maps operation names (see Op.String) to their statistics. Only
+	// operations that have been called are included.
+	Ops map[string]OpStats
+	// HighWater is the largest length observed.
+	HighWater int
+	// LockWaits is the number of times a lock was acquired, and LockWait the
+	// total time spent waiting to acquire it.
+	LockWaits int64
+	LockWait  time.Duration
+	// Shrinks is the number of times a slice backed container shrank its
+	// backing slice.
+	Shrinks int64
+}
+
+// Stats returns a copy of the current statistics.
+func (m *Metrics) Stats() Stats {
+	stats := Stats{Ops: map[string]OpStats{}}
+	if m == nil {
+		return stats
+	}
+
+	for op := Op(0); op < numOps; op++ {
+		if count := m.counts[op].Load(); count > 0 {
+			stats.Ops[op.String()] = OpStats{count, time.Duration(m.latencies[op].Load())}
+		}
+	}
+	stats.HighWater = int(m.highWater.Load())
+	stats.LockWaits = m.lockWaits.Load()
+	stats.LockWait = time.Duration(m.lockWait.Load())
+	stats.Shrinks = m.shrinks.Load()
+
+	return stats
+}
+
+// Publish exports the statistics through expvar under the given name. Like
+// expvar.Publish, it panics if the name is already in use.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return m.Stats()
+	}))
+}
@@ -0,0 +1,76 @@
+package adts
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+)
+
+func TestMetricsNil(t *testing.T) {
+	var m *Metrics
+
+	// A nil Metrics should record nothing and never panic.
+	m.ObserveOp(OpAdd, time.Now())
+	m.ObserveLen(10)
+	m.ObserveShrink()
+	container := MakeSliceContainerThreadSafe()
+	m.Lock(container.Lock)
+	container.Lock.Unlock()
+
+	if stats := m.Stats(); len(stats.Ops) != 0 || stats.HighWater != 0 {
+		t.Errorf("Nil metrics should have empty stats. Actual: %v", stats)
+	}
+}
+
+func TestMetricsSliceContainer(t *testing.T) {
+	m := MakeMetrics()
+	container := MakeSliceContainerThreadSafe()
+	container.Metrics = m
+
+	for i := 0; i < 100; i++ {
+		container.Add(IntElt(i))
+	}
+	for i := 0; i < 100; i++ {
+		container.Remove(IntElt(i))
+	}
+
+	stats := m.Stats()
+	if stats.LockWaits != 200 {
+		t.Errorf("Every Add and Remove should record a lock wait. Expected: %d, Actual: %d", 200, stats.LockWaits)
+	}
+	if stats.Shrinks == 0 {
+		t.Error("Removing every element should have shrunk the backing slice.")
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	m := MakeMetrics()
+	c := Instrument(MakeSliceContainer(), m)
+
+	for i := 0; i < 10; i++ {
+		c.Add(IntElt(i))
+	}
+	c.Remove(IntElt(0))
+	c.Contains(IntElt(1))
+
+	stats := m.Stats()
+	if stats.Ops["Add"].Count != 10 || stats.Ops["Remove"].Count != 1 || stats.Ops["Contains"].Count != 1 {
+		t.Errorf("Wrong operation counts: %v", stats.Ops)
+	}
+	if _, ok := stats.Ops["Clear"]; ok {
+		t.Error("Operations that were never called should not be in the stats.")
+	}
+	if stats.HighWater != 10 {
+		t.Errorf("Expected high-water mark: %d, Actual: %d", 10, stats.HighWater)
+	}
+
+	m.Publish("adts_test_metrics")
+	var published Stats
+	if err := json.Unmarshal([]byte(expvar.Get("adts_test_metrics").String()), &published); err != nil {
+		t.Fatalf("Published stats are not valid JSON: %v", err)
+	}
+	if published.HighWater != 10 {
+		t.Errorf("Published high-water mark is wrong. Expected: %d, Actual: %d", 10, published.HighWater)
+	}
+}
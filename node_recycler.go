@@ -0,0 +1,17 @@
+package adts
+
+import (
+	"github.com/johnsrd7/go-adts/internal/nodelist"
+)
+
+// NodeRecycler keeps the nodes that ListStack and ListQueue remove so later
+// pushes can reuse them instead of allocating. It's backed by a sync.Pool, so
+// it's safe to share between any number of containers and goroutines, and
+// idle nodes are released by the garbage collector. A nil *NodeRecycler
+// allocates every node.
+type NodeRecycler = nodelist.Recycler[ContainerElement]
+
+// MakeNodeRecycler creates a new, empty NodeRecycler.
+func MakeNodeRecycler() *NodeRecycler {
+	return nodelist.MakeRecycler[ContainerElement]()
+}
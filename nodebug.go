@@ -0,0 +1,8 @@
+//go:build !adtsdebug
+
+package adts
+
+// Debug is true when the package is built with the adtsdebug tag. In debug
+// mode iterators panic on concurrent modification instead of returning
+// ErrConcurrentModification, so the bug surfaces where it happens.
+const Debug = false
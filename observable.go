@@ -0,0 +1,221 @@
+package adts
+
+import (
+	"sync"
+)
+
+// EventKind identifies the kind of change an Event describes.
+type EventKind int
+
+// The kinds of change an observable container reports.
+const (
+	Added EventKind = iota
+	Removed
+	Set
+	Cleared
+	Pushed
+	Popped
+	Enqueued
+	Dequeued
+)
+
+var eventKindNames = [...]string{"Added", "Removed", "Set", "Cleared", "Pushed", "Popped", "Enqueued", "Dequeued"}
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventKindNames) {
+		return "Unknown"
+	}
+
+	return eventKindNames[k]
+}
+
+// Event describes a single change to an observed container.
+type Event struct {
+	Kind EventKind
+	// Elt is the element that was added or removed, or the new value for Set.
+	// It's nil for Cleared.
+	Elt ContainerElement
+	// Old is the value that was replaced by Set. It's nil for other events.
+	Old ContainerElement
+	// Index is the index that was changed by Set. It's -1 for other events.
+	Index int
+}
+
+// Listener is a function that gets called with every event of an observed container.
+type Listener func(Event)
+
+// Notifier keeps track of the listeners of an observed container and
+// delivers events to them. The observable wrappers embed a Notifier.
+type Notifier struct {
+	lock      *sync.Mutex
+	listeners []subscription
+	nextID    int
+}
+
+// subscription is a registered listener. Notifier keeps them in the order
+// they subscribed.
+type subscription struct {
+	id int
+	fn Listener
+}
+
+// MakeNotifier creates a new Notifier without any listeners.
+func MakeNotifier() *Notifier {
+	return &Notifier{&sync.Mutex{}, nil, 0}
+}
+
+// Subscribe registers fn to be called synchronously with every event and
+// returns a function that unregisters it.
+func (n *Notifier) Subscribe(fn Listener) (unsubscribe func()) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	id := n.nextID
+	n.nextID++
+	n.listeners = append(n.listeners, subscription{id, fn})
+
+	return func() {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		for idx, sub := range n.listeners {
+			if sub.id == id {
+				// Notify may still be ranging over the old slice, so build a
+				// new one rather than shifting elements in place.
+				n.listeners = append(n.listeners[:idx:idx], n.listeners[idx+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SubscribeChan returns a channel with the given buffer size that receives
+// every event, and a function that unregisters and closes the channel. When
+// the buffer is full, the change that caused the event blocks until the
+// event can be delivered, so events are never dropped or reordered. Events
+// that are still being delivered when the channel is closed are dropped.
+func (n *Notifier) SubscribeChan(size int) (<-chan Event, func()) {
+	events := make(chan Event, size)
+	done := make(chan struct{})
+	sendLock := &sync.Mutex{}
+	closed := false
+
+	unsubscribe := n.Subscribe(func(e Event) {
+		sendLock.Lock()
+		defer sendLock.Unlock()
+		// Notify may have picked up this listener just before it was
+		// unsubscribed, so the channel may already be closed.
+		if closed {
+			return
+		}
+		select {
+		case events <- e:
+		case <-done:
+		}
+	})
+
+	once := &sync.Once{}
+	return events, func() {
+		once.Do(func() {
+			unsubscribe()
+			// Closing done first unblocks a send that's waiting on a full
+			// buffer, so the send lock can be acquired to close the channel.
+			close(done)
+			sendLock.Lock()
+			defer sendLock.Unlock()
+			closed = true
+			close(events)
+		})
+	}
+}
+
+// Notify delivers the given event to every listener, in the order they
+// subscribed.
+func (n *Notifier) Notify(e Event) {
+	n.lock.Lock()
+	listeners := n.listeners
+	n.lock.Unlock()
+
+	for _, sub := range listeners {
+		sub.fn(e)
+	}
+}
+
+// ObservableContainer wraps any Container and reports every change made
+// through it to the registered listeners.
+//
+// Changes are serialized by the wrapper, and listeners are called
+// synchronously, in the order the changes were made, before the changing
+// method returns. Listeners must not change the container, since that would
+// deadlock. The wrapper doesn't make reads safe, so for concurrent use the
+// wrapped container should be threadsafe.
+type ObservableContainer struct {
+	inner Container
+	lock  *sync.Mutex
+
+	*Notifier
+}
+
+// Observe wraps the given container so that its changes can be observed.
+func Observe(c Container) *ObservableContainer {
+	return &ObservableContainer{c, &sync.Mutex{}, MakeNotifier()}
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the container.
+func (oc *ObservableContainer) Len() int {
+	return oc.inner.Len()
+}
+
+// IsEmpty returns if the container is empty or not.
+func (oc *ObservableContainer) IsEmpty() bool {
+	return oc.inner.IsEmpty()
+}
+
+// Clear removes all elements from the container.
+func (oc *ObservableContainer) Clear() {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	oc.inner.Clear()
+	oc.Notify(Event{Kind: Cleared, Index: -1})
+}
+
+// Contains returns true if the given item is in the container.
+func (oc *ObservableContainer) Contains(item ContainerElement) bool {
+	return oc.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the container.
+func (oc *ObservableContainer) Add(item ContainerElement) bool {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	if !oc.inner.Add(item) {
+		return false
+	}
+
+	oc.Notify(Event{Kind: Added, Elt: item, Index: -1})
+	return true
+}
+
+// Remove returns true if the given element was removed.
+func (oc *ObservableContainer) Remove(item ContainerElement) bool {
+	_, ok := oc.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (oc *ObservableContainer) RemoveElement(item ContainerElement) (ContainerElement, bool) {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+	elt, ok := RemoveElement(oc.inner, item)
+	if !ok {
+		return elt, false
+	}
+
+	oc.Notify(Event{Kind: Removed, Elt: elt, Index: -1})
+	return elt, true
+}
@@ -0,0 +1,163 @@
+package adts
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestObserveSubscribe(t *testing.T) {
+	c := Observe(MakeSliceContainer())
+
+	events := []Event{}
+	unsubscribe := c.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+
+	c.Add(IntElt(1))
+	c.Add(IntElt(2))
+	c.Remove(IntElt(1))
+	c.Remove(IntElt(5))
+	c.Clear()
+
+	expected := []Event{
+		{Kind: Added, Elt: IntElt(1), Index: -1},
+		{Kind: Added, Elt: IntElt(2), Index: -1},
+		{Kind: Removed, Elt: IntElt(1), Index: -1},
+		{Kind: Cleared, Index: -1},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, Actual: %d (%v)", len(expected), len(events), events)
+	}
+	for idx, e := range expected {
+		if events[idx] != e {
+			t.Errorf("Wrong event #%d. Expected: %v, Actual: %v", idx, e, events[idx])
+		}
+	}
+
+	unsubscribe()
+	c.Add(IntElt(3))
+	if len(events) != len(expected) {
+		t.Error("Listener should not be called after unsubscribing.")
+	}
+}
+
+func TestObserveRemoveReportsRemovedElement(t *testing.T) {
+	c := Observe(Synchronized(MakeSliceContainer(lastDigit)))
+	var removed ContainerElement
+	c.Subscribe(func(e Event) {
+		removed = e.Elt
+	})
+
+	c.Add(IntElt(11))
+	if elt, ok := c.RemoveElement(IntElt(21)); !ok || !elt.Equals(IntElt(11)) {
+		t.Errorf("RemoveElement should return the removed element. Expected: %v, Actual: %v", 11, elt)
+	}
+	if removed == nil || !removed.Equals(IntElt(11)) {
+		t.Errorf("The event should carry the removed element. Expected: %v, Actual: %v", 11, removed)
+	}
+
+	// A container without RemoveElement can only report the argument.
+	plain := Observe(struct{ Container }{MakeSliceContainer(lastDigit)})
+	plain.Subscribe(func(e Event) {
+		removed = e.Elt
+	})
+	plain.Add(IntElt(12))
+	if !plain.Remove(IntElt(22)) || !removed.Equals(IntElt(22)) {
+		t.Errorf("Expected the argument to be reported. Expected: %v, Actual: %v", 22, removed)
+	}
+	if _, ok := RemoveElement(plain, IntElt(22)); ok {
+		t.Error("RemoveElement of a missing element should fail.")
+	}
+}
+
+func TestObserveSubscribeChan(t *testing.T) {
+	c := Observe(MakeSliceContainerThreadSafe())
+	events, unsubscribe := c.SubscribeChan(10)
+
+	// Events should arrive in the order the adds happened even though the
+	// buffer is much smaller than the number of adds.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			c.Add(IntElt(i))
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		e := <-events
+		if e.Kind != Added || !e.Elt.Equals(IntElt(i)) {
+			t.Errorf("Events were delivered out of order. Expected: %v, Actual: %v", i, e.Elt)
+			break
+		}
+	}
+	wg.Wait()
+
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("Channel should be closed after unsubscribing.")
+	}
+
+	// Unsubscribing again must be a no-op, and later changes must not block.
+	unsubscribe()
+	c.Add(IntElt(0))
+}
+
+func TestObserveSubscribeChanUnsubscribeRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n := MakeNotifier()
+
+		// The first listener holds Notify up after it has picked up both
+		// listeners, so the channel is unsubscribed before its listener runs.
+		entered, gate := make(chan struct{}), make(chan struct{})
+		n.Subscribe(func(Event) {
+			close(entered)
+			<-gate
+		})
+		_, unsubscribe := n.SubscribeChan(0)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			n.Notify(Event{Kind: Added, Elt: IntElt(0), Index: -1})
+		}()
+
+		<-entered
+		unsubscribe()
+		close(gate)
+		<-done
+	}
+}
+
+func TestNotifierOrder(t *testing.T) {
+	n := MakeNotifier()
+	var order []int
+	var unsubscribes []func()
+	for i := 0; i < 4; i++ {
+		i := i
+		unsubscribes = append(unsubscribes, n.Subscribe(func(Event) {
+			order = append(order, i)
+		}))
+	}
+
+	unsubscribes[1]()
+	unsubscribes[1]()
+	n.Notify(Event{Kind: Cleared, Index: -1})
+
+	expected := []int{0, 2, 3}
+	if len(order) != len(expected) {
+		t.Fatalf("Wrong listeners called. Expected: %v, Actual: %v", expected, order)
+	}
+	for idx, id := range expected {
+		if order[idx] != id {
+			t.Errorf("Listeners were called out of order. Expected: %v, Actual: %v", expected, order)
+		}
+	}
+}
+
+func TestEventKindString(t *testing.T) {
+	if Dequeued.String() != "Dequeued" || EventKind(100).String() != "Unknown" {
+		t.Error("EventKind has the wrong name.")
+	}
+}
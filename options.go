@@ -0,0 +1,95 @@
+package adts
+
+// Options holds the settings used by the New* constructors. Settings that
+// don't apply to a container (like Capacity for a linked list) are ignored.
+type Options struct {
+	ThreadSafe     bool
+	Capacity       int
+	ShrinkFactor   float32
+	Equal          EqualFunc
+	Metrics        *Metrics
+	CapacityPolicy CapacityPolicy
+	NodeCapacity   int
+	Recycler       *NodeRecycler
+}
+
+// Option changes one setting in an Options.
+type Option func(*Options)
+
+// MakeOptions returns the default Options with the given options applied.
+func MakeOptions(opts ...Option) Options {
+	o := Options{ShrinkFactor: 0.25}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithThreadSafety makes the container threadsafe.
+func WithThreadSafety() Option {
+	return func(o *Options) {
+		o.ThreadSafe = true
+	}
+}
+
+// WithCapacity preallocates room for n elements in slice backed containers.
+func WithCapacity(n int) Option {
+	return func(o *Options) {
+		if n < 0 {
+			n = 0
+		}
+		o.Capacity = n
+	}
+}
+
+// WithShrinkFactor sets the fraction of capacity below which slice backed
+// containers shrink their backing slice after a removal. The default is 0.25.
+func WithShrinkFactor(f float32) Option {
+	return func(o *Options) {
+		o.ShrinkFactor = f
+	}
+}
+
+// WithEquality makes the container compare elements with fn instead of
+// ContainerElement.Equals. A nil fn keeps the default.
+func WithEquality(fn EqualFunc) Option {
+	return func(o *Options) {
+		o.Equal = fn
+	}
+}
+
+// WithCapacityPolicy makes slice backed containers grow and shrink according
+// to the given policy instead of ShrinkFactor.
+func WithCapacityPolicy(p CapacityPolicy) Option {
+	return func(o *Options) {
+		o.CapacityPolicy = p
+	}
+}
+
+// WithNodeCapacity sets how many elements each node of a node based
+// container (like an unrolled list) holds. Zero or less keeps the
+// container's default.
+func WithNodeCapacity(n int) Option {
+	return func(o *Options) {
+		if n < 0 {
+			n = 0
+		}
+		o.NodeCapacity = n
+	}
+}
+
+// WithNodeRecycler makes linked containers take their nodes from r and give
+// removed nodes back to it. One recycler can be shared by many containers.
+func WithNodeRecycler(r *NodeRecycler) Option {
+	return func(o *Options) {
+		o.Recycler = r
+	}
+}
+
+// WithMetrics attaches the given metrics to the container.
+func WithMetrics(m *Metrics) Option {
+	return func(o *Options) {
+		o.Metrics = m
+	}
+}
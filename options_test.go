@@ -0,0 +1,40 @@
+package adts
+
+import "testing"
+
+func TestMakeOptions(t *testing.T) {
+	o := MakeOptions()
+	if o.ThreadSafe || o.Capacity != 0 || o.ShrinkFactor != 0.25 || o.Equal != nil || o.Metrics != nil || o.NodeCapacity != 0 {
+		t.Errorf("Unexpected default options. Actual: %+v", o)
+	}
+
+	m, r := MakeMetrics(), MakeNodeRecycler()
+	o = MakeOptions(WithThreadSafety(), WithCapacity(-1), WithShrinkFactor(0.1), WithEquality(lastDigit), WithMetrics(m), WithNodeCapacity(-1), WithNodeRecycler(r))
+	if !o.ThreadSafe || o.Capacity != 0 || o.ShrinkFactor != 0.1 || o.Equal == nil || o.Metrics != m || o.NodeCapacity != 0 || o.Recycler != r {
+		t.Errorf("Options weren't applied. Actual: %+v", o)
+	}
+}
+
+func TestNewSliceContainer(t *testing.T) {
+	m := MakeMetrics()
+	container := NewSliceContainer(WithThreadSafety(), WithCapacity(64), WithShrinkFactor(0), WithEquality(lastDigit), WithMetrics(m))
+
+	if !container.ThreadSafe || container.Metrics != m || container.ShrinkFactor != 0 {
+		t.Errorf("NewSliceContainer didn't apply its options. Actual: %+v", container)
+	}
+	if cap(container.Backer) != 64 || len(container.Backer) != 0 {
+		t.Errorf("Wrong capacity. Expected: %d, Actual: %d", 64, cap(container.Backer))
+	}
+
+	container.Add(IntElt(1))
+	if !container.Contains(IntElt(11)) {
+		t.Error("NewSliceContainer should use the given EqualFunc.")
+	}
+
+	// A shrink factor of 0 only shrinks once the container is empty.
+	container.Add(IntElt(2))
+	container.Remove(IntElt(2))
+	if cap(container.Backer) != 64 {
+		t.Errorf("Container shrank too early. Expected: %d, Actual: %d", 64, cap(container.Backer))
+	}
+}
@@ -0,0 +1,107 @@
+package queueadts_test
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	"github.com/johnsrd7/go-adts/adtstest"
+	queueadts "github.com/johnsrd7/go-adts/queues"
+)
+
+func TestSliceQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.MakeSliceQueue() })
+}
+
+func TestSliceQueueThreadSafeConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.MakeSliceQueueThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestListQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.MakeListQueue() })
+}
+
+func TestListQueueThreadSafeConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.MakeListQueueThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestSyncQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue {
+		return queueadts.SynchronizedQueue(queueadts.MakeListQueue())
+	}, adtstest.ThreadSafe())
+}
+
+func TestObservableQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.ObserveQueue(queueadts.MakeSliceQueue()) })
+}
+
+func TestInstrumentedQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue {
+		return queueadts.InstrumentQueue(queueadts.MakeListQueue(), adts.MakeMetrics())
+	})
+}
+
+func TestSliceQueueLinearizability(t *testing.T) {
+	adtstest.RunQueueLinearizability(t, func() queueadts.Queue { return queueadts.MakeSliceQueueThreadSafe() })
+}
+
+func TestListQueueLinearizability(t *testing.T) {
+	adtstest.RunQueueLinearizability(t, func() queueadts.Queue { return queueadts.MakeListQueueThreadSafe() })
+}
+
+func TestNewSliceQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue {
+		return queueadts.NewSliceQueue(adts.WithThreadSafety(), adts.WithCapacity(8), adts.WithMetrics(adts.MakeMetrics()))
+	}, adtstest.ThreadSafe())
+}
+
+func TestNewListQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue {
+		return queueadts.NewListQueue(adts.WithThreadSafety(), adts.WithCapacity(8), adts.WithMetrics(adts.MakeMetrics()))
+	}, adtstest.ThreadSafe())
+}
+
+func TestSliceQueueCapacityPolicyConformance(t *testing.T) {
+	for _, policy := range []adts.CapacityPolicy{adts.NeverShrink{}, adts.Hysteresis{ShrinkBelow: 0.25, Target: 0.5}, adts.FixedStep{Step: 4}, adts.MemoryBudget{MaxElements: 1 << 20}} {
+		adtstest.RunQueueSuite(t, func() queueadts.Queue {
+			return queueadts.NewSliceQueue(adts.WithCapacityPolicy(policy))
+		})
+	}
+}
+
+func TestRecycledListQueueConformance(t *testing.T) {
+	r := adts.MakeNodeRecycler()
+	adtstest.RunQueueSuite(t, func() queueadts.Queue {
+		return queueadts.NewListQueue(adts.WithThreadSafety(), adts.WithNodeRecycler(r))
+	}, adtstest.ThreadSafe())
+}
+
+func TestRecycledListQueueLinearizability(t *testing.T) {
+	r := adts.MakeNodeRecycler()
+	adtstest.RunQueueLinearizability(t, func() queueadts.Queue {
+		return queueadts.NewListQueue(adts.WithThreadSafety(), adts.WithNodeRecycler(r))
+	})
+}
+
+func TestSegmentedQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.NewSegmentedQueue(adts.WithNodeCapacity(3)) })
+}
+
+func TestSegmentedQueueThreadSafeConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue {
+		return queueadts.NewSegmentedQueue(adts.WithThreadSafety(), adts.WithNodeCapacity(3))
+	}, adtstest.ThreadSafe())
+}
+
+func TestSegmentedQueueLinearizability(t *testing.T) {
+	adtstest.RunQueueLinearizability(t, func() queueadts.Queue {
+		return queueadts.NewSegmentedQueue(adts.WithThreadSafety(), adts.WithNodeCapacity(2))
+	})
+}
+
+func TestTwoLockQueueConformance(t *testing.T) {
+	adtstest.RunQueueSuite(t, func() queueadts.Queue { return queueadts.MakeTwoLockQueue() }, adtstest.ThreadSafe())
+}
+
+func TestTwoLockQueueLinearizability(t *testing.T) {
+	adtstest.RunQueueLinearizability(t, func() queueadts.Queue { return queueadts.MakeTwoLockQueue() })
+}
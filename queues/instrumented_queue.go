@@ -0,0 +1,93 @@
+package queueadts
+
+import (
+	"time"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// InstrumentedQueue wraps any Queue and records the count and latency of every
+// operation, and the high-water mark of its length, in a adts.Metrics.
+type InstrumentedQueue struct {
+	inner   Queue
+	metrics *adts.Metrics
+}
+
+// InstrumentQueue wraps the given queue so that its operations are recorded in m.
+func InstrumentQueue(q Queue, m *adts.Metrics) *InstrumentedQueue {
+	return &InstrumentedQueue{q, m}
+}
+
+// Metrics returns the metrics the wrapper records into.
+func (i *InstrumentedQueue) Metrics() *adts.Metrics {
+	return i.metrics
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the queue.
+func (i *InstrumentedQueue) Len() int {
+	defer i.metrics.ObserveOp(adts.OpLen, time.Now())
+	return i.inner.Len()
+}
+
+// IsEmpty returns if the queue is empty or not.
+func (i *InstrumentedQueue) IsEmpty() bool {
+	defer i.metrics.ObserveOp(adts.OpIsEmpty, time.Now())
+	return i.inner.IsEmpty()
+}
+
+// Clear removes all elements from the queue.
+func (i *InstrumentedQueue) Clear() {
+	defer i.metrics.ObserveOp(adts.OpClear, time.Now())
+	i.inner.Clear()
+}
+
+// Contains returns true if the given item is in the queue.
+func (i *InstrumentedQueue) Contains(item adts.ContainerElement) bool {
+	defer i.metrics.ObserveOp(adts.OpContains, time.Now())
+	return i.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the queue.
+func (i *InstrumentedQueue) Add(item adts.ContainerElement) bool {
+	start := time.Now()
+	added := i.inner.Add(item)
+	i.metrics.ObserveOp(adts.OpAdd, start)
+	i.metrics.ObserveLen(i.inner.Len())
+	return added
+}
+
+// Remove returns true if the given element was removed.
+func (i *InstrumentedQueue) Remove(item adts.ContainerElement) bool {
+	defer i.metrics.ObserveOp(adts.OpRemove, time.Now())
+	return i.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (i *InstrumentedQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	defer i.metrics.ObserveOp(adts.OpRemove, time.Now())
+	return adts.RemoveElement(i.inner, item)
+}
+
+// -------------------------------------------------------
+// Queue Methods
+// -------------------------------------------------------
+
+// Enqueue pushes the given element onto the back of the queue.
+func (i *InstrumentedQueue) Enqueue(item adts.ContainerElement) bool {
+	start := time.Now()
+	enqueued := i.inner.Enqueue(item)
+	i.metrics.ObserveOp(adts.OpEnqueue, start)
+	i.metrics.ObserveLen(i.inner.Len())
+	return enqueued
+}
+
+// Dequeue removes the element from the front of the queue and returns the element.
+func (i *InstrumentedQueue) Dequeue() (adts.ContainerElement, bool) {
+	defer i.metrics.ObserveOp(adts.OpDequeue, time.Now())
+	return i.inner.Dequeue()
+}
@@ -0,0 +1,25 @@
+package queueadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestInstrumentQueue(t *testing.T) {
+	m := adts.MakeMetrics()
+
+	var q Queue
+	q = InstrumentQueue(MakeListQueue(), m)
+	for i := 0; i < 10; i++ {
+		q.Enqueue(adts.IntElt(i))
+	}
+	for i := 0; i < 5; i++ {
+		q.Dequeue()
+	}
+
+	stats := m.Stats()
+	if stats.Ops["Enqueue"].Count != 10 || stats.Ops["Dequeue"].Count != 5 || stats.HighWater != 10 {
+		t.Errorf("Wrong stats: %v", stats)
+	}
+}
@@ -1,27 +1,49 @@
 package queueadts
 
 import (
-	"container/list"
 	"sync"
 
 	adts "github.com/johnsrd7/go-adts"
+	"github.com/johnsrd7/go-adts/internal/nodelist"
 )
 
 // ListQueue is a simple type that implements the Stack interface (both threadsafe and not).
 type ListQueue struct {
-	backer     *list.List
-	lock       *sync.Mutex
+	backer     *nodelist.List[adts.ContainerElement]
+	lock       *sync.RWMutex
 	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+
+	// modCount counts structural changes so iterators can detect them.
+	modCount uint64
+}
+
+// NewListQueue creates a new ListQueue configured by the given options.
+// Capacity and ShrinkFactor don't apply and are ignored. With
+// adts.WithNodeRecycler the queue reuses the nodes of removed elements.
+func NewListQueue(opts ...adts.Option) *ListQueue {
+	o := adts.MakeOptions(opts...)
+	return &ListQueue{nodelist.MakeList(o.Recycler), &sync.RWMutex{}, o.ThreadSafe, o.Metrics, o.Equal, 0}
 }
 
-// MakeListQueue creates a non-threadsafe ListQueue
-func MakeListQueue() *ListQueue {
-	return &ListQueue{list.New(), &sync.Mutex{}, false}
+// MakeListQueue creates a new non-threadsafe ListQueue. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the queue.
+func MakeListQueue(eq ...adts.EqualFunc) *ListQueue {
+	return NewListQueue(adts.WithEquality(firstEqual(eq)))
 }
 
-// MakeListQueueThreadSafe creates a threadsafe ListQueue
-func MakeListQueueThreadSafe() *ListQueue {
-	return &ListQueue{list.New(), &sync.Mutex{}, true}
+// MakeListQueueThreadSafe creates a new threadsafe ListQueue. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the queue.
+func MakeListQueueThreadSafe(eq ...adts.EqualFunc) *ListQueue {
+	return NewListQueue(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the queue, which then records how
+// long it waits on its lock. It must be called before the queue is shared
+// between goroutines.
+func (lq *ListQueue) SetMetrics(m *adts.Metrics) {
+	lq.metrics = m
 }
 
 // -------------------------------------------------------
@@ -31,8 +53,8 @@ func MakeListQueueThreadSafe() *ListQueue {
 // Len returns the number of elements in the queue.
 func (lq *ListQueue) Len() int {
 	if lq.threadSafe {
-		lq.lock.Lock()
-		defer lq.lock.Unlock()
+		lq.metrics.Lock(lq.lock.RLocker())
+		defer lq.lock.RUnlock()
 		return lq.backer.Len()
 	}
 
@@ -47,20 +69,26 @@ func (lq *ListQueue) IsEmpty() bool {
 // Clear removes all elements from the queue.
 func (lq *ListQueue) Clear() {
 	if lq.threadSafe {
-		lq.lock.Lock()
+		lq.metrics.Lock(lq.lock)
 		defer lq.lock.Unlock()
-		lq.backer.Init()
+		lq.clearHelper()
 		return
 	}
 
+	lq.clearHelper()
+}
+
+// clearHelper removes every element (in a non-threadsafe way).
+func (lq *ListQueue) clearHelper() {
 	lq.backer.Init()
+	lq.modCount++
 }
 
 // Contains returns true if the given item is in the queue.
 func (lq *ListQueue) Contains(item adts.ContainerElement) bool {
 	if lq.threadSafe {
-		lq.lock.Lock()
-		defer lq.lock.Unlock()
+		lq.metrics.Lock(lq.lock.RLocker())
+		defer lq.lock.RUnlock()
 		return lq.containsHelper(item)
 	}
 
@@ -70,10 +98,8 @@ func (lq *ListQueue) Contains(item adts.ContainerElement) bool {
 // containsHelper checks the list for the given element (in a non-threadsafe way).
 func (lq *ListQueue) containsHelper(item adts.ContainerElement) bool {
 	for tmp := lq.backer.Front(); tmp != nil; tmp = tmp.Next() {
-		if v, ok := tmp.Value.(adts.ContainerElement); ok {
-			if v.Equals(item) {
-				return true
-			}
+		if lq.equal.Equal(tmp.Elt, item) {
+			return true
 		}
 	}
 
@@ -83,18 +109,32 @@ func (lq *ListQueue) containsHelper(item adts.ContainerElement) bool {
 // Add returns true if the given element was added to the top of the queue.
 func (lq *ListQueue) Add(item adts.ContainerElement) bool {
 	if lq.threadSafe {
-		lq.lock.Lock()
+		lq.metrics.Lock(lq.lock)
 		defer lq.lock.Unlock()
-		return lq.backer.PushBack(item) != nil
+		return lq.addHelper(item)
 	}
 
-	return lq.backer.PushBack(item) != nil
+	return lq.addHelper(item)
+}
+
+// addHelper adds the element to the list (in a non-threadsafe way).
+func (lq *ListQueue) addHelper(item adts.ContainerElement) bool {
+	lq.backer.PushBack(item)
+	lq.modCount++
+	return true
 }
 
 // Remove returns true if the given element was removed.
 func (lq *ListQueue) Remove(item adts.ContainerElement) bool {
+	_, ok := lq.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (lq *ListQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
 	if lq.threadSafe {
-		lq.lock.Lock()
+		lq.metrics.Lock(lq.lock)
 		defer lq.lock.Unlock()
 		return lq.removeHelper(item)
 	}
@@ -102,17 +142,16 @@ func (lq *ListQueue) Remove(item adts.ContainerElement) bool {
 	return lq.removeHelper(item)
 }
 
-func (lq *ListQueue) removeHelper(item adts.ContainerElement) bool {
+func (lq *ListQueue) removeHelper(item adts.ContainerElement) (adts.ContainerElement, bool) {
 	for tmp := lq.backer.Front(); tmp != nil; tmp = tmp.Next() {
-		if v, ok := tmp.Value.(adts.ContainerElement); ok {
-			if v.Equals(item) {
-				removed, ok := lq.backer.Remove(tmp).(adts.ContainerElement)
-				return ok && removed.Equals(item)
-			}
+		if lq.equal.Equal(tmp.Elt, item) {
+			elt := lq.backer.Remove(tmp)
+			lq.modCount++
+			return elt, true
 		}
 	}
 
-	return false
+	return adts.EmptyContainerElement{}, false
 }
 
 // -------------------------------------------------------
@@ -127,7 +166,7 @@ func (lq *ListQueue) Enqueue(item adts.ContainerElement) bool {
 // Dequeue removes the element from the front of the queue and returns the element.
 func (lq *ListQueue) Dequeue() (adts.ContainerElement, bool) {
 	if lq.threadSafe {
-		lq.lock.Lock()
+		lq.metrics.Lock(lq.lock)
 		defer lq.lock.Unlock()
 		return lq.dequeueHelper()
 	}
@@ -137,16 +176,384 @@ func (lq *ListQueue) Dequeue() (adts.ContainerElement, bool) {
 
 // dequeueHelper removes the element from the front of the queue and returns the element.
 func (lq *ListQueue) dequeueHelper() (adts.ContainerElement, bool) {
-	// Have to use list.List's Len function since we might already
+	// Have to use the backing list's Len function since we might already
 	// be inside a lock and we don't want to deadlock.
 	if lq.backer.Len() == 0 {
 		return adts.EmptyContainerElement{}, false
 	}
 
-	if lastElt, ok := lq.backer.Front().Value.(adts.ContainerElement); ok {
-		lq.backer.Remove(lq.backer.Front())
-		return lastElt, true
+	lastElt := lq.backer.Remove(lq.backer.Front())
+	lq.modCount++
+	return lastElt, true
+}
+
+// -------------------------------------------------------
+// Relinking Methods
+// -------------------------------------------------------
+
+// Concat moves every element of other to the back of the queue, keeping
+// their order, and leaves other empty. It takes O(1) time. Both queues are
+// locked (in adts.LockOrder order) if they're threadsafe. It returns false
+// if other is the queue itself.
+func (lq *ListQueue) Concat(other *ListQueue) bool {
+	if other == lq {
+		return false
+	}
+
+	defer lockPair(lq, other)()
+
+	if other.backer.Len() == 0 {
+		return true
 	}
 
-	return adts.EmptyContainerElement{}, false
+	lq.backer.Append(other.backer)
+	lq.modCount++
+	other.modCount++
+	return true
+}
+
+// lockPair takes the write locks of both queues (if threadsafe) in
+// adts.LockOrder order and returns a function that releases them.
+func lockPair(a, b *ListQueue) func() {
+	first, second := a, b
+	if adts.LockOrder(b) < adts.LockOrder(a) {
+		first, second = b, a
+	}
+
+	for _, lq := range []*ListQueue{first, second} {
+		if lq.threadSafe {
+			lq.metrics.Lock(lq.lock)
+		}
+	}
+
+	return func() {
+		for _, lq := range []*ListQueue{second, first} {
+			if lq.threadSafe {
+				lq.lock.Unlock()
+			}
+		}
+	}
+}
+
+// SplitAt keeps the first idx elements in the queue and returns the ones
+// after them as a new queue with the same settings. It walks from whichever
+// end of the queue is closer to idx.
+func (lq *ListQueue) SplitAt(idx int) *ListQueue {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock)
+		defer lq.lock.Unlock()
+		return lq.splitAtHelper(idx)
+	}
+
+	return lq.splitAtHelper(idx)
+}
+
+// splitAtHelper moves the elements from idx on into a new queue.
+func (lq *ListQueue) splitAtHelper(idx int) *ListQueue {
+	rest := lq.emptyLike()
+	rest.backer = lq.backer.SplitAt(idx)
+	lq.modCount++
+	return rest
+}
+
+// Reverse reverses the order of the queue in place.
+func (lq *ListQueue) Reverse() {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock)
+		defer lq.lock.Unlock()
+		lq.reverseHelper()
+		return
+	}
+
+	lq.reverseHelper()
+}
+
+// reverseHelper swaps the links of every node, so nothing is allocated.
+func (lq *ListQueue) reverseHelper() {
+	lq.backer.Reverse()
+	lq.modCount++
+}
+
+// Rotate moves the first n elements to the back of the queue, keeping their
+// order. A negative n moves the last -n elements to the front instead.
+func (lq *ListQueue) Rotate(n int) {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock)
+		defer lq.lock.Unlock()
+		lq.rotateHelper(n)
+		return
+	}
+
+	lq.rotateHelper(n)
+}
+
+// rotateHelper relinks the list into a ring and cuts it before the new
+// front, walking to it from whichever end is closer.
+func (lq *ListQueue) rotateHelper(n int) {
+	n = rotation(n, lq.backer.Len())
+	if n == 0 {
+		return
+	}
+
+	lq.backer.Rotate(n)
+	lq.modCount++
+}
+
+// rotation reduces a rotation by n of a queue of the given length to the
+// equivalent rotation in [0, length).
+func rotation(n, length int) int {
+	if length == 0 {
+		return 0
+	}
+
+	n %= length
+	if n < 0 {
+		n += length
+	}
+
+	return n
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the queue from front to back. Its Err
+// returns adts.ErrConcurrentModification if the queue is changed other than
+// through the iterator's Remove.
+func (lq *ListQueue) Iterator() adts.MutableIterator {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock.RLocker())
+		defer lq.lock.RUnlock()
+	}
+
+	return &listQueueIterator{lq: lq, expected: lq.modCount}
+}
+
+// listQueueIterator is the fail-fast iterator of a ListQueue. It remembers the
+// element after the current one so the current one can be removed, and copies
+// the current element so Value never reads a recycled node.
+type listQueueIterator struct {
+	lq        *ListQueue
+	cur       *nodelist.Node[adts.ContainerElement]
+	next      *nodelist.Node[adts.ContainerElement]
+	val       adts.ContainerElement
+	started   bool
+	expected  uint64
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the queue has been changed.
+func (it *listQueueIterator) Next() bool {
+	if it.lq.threadSafe {
+		it.lq.metrics.Lock(it.lq.lock.RLocker())
+		defer it.lq.lock.RUnlock()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.lq.modCount); it.err != nil {
+		return false
+	}
+
+	if !it.started {
+		it.next, it.started = it.lq.backer.Front(), true
+	}
+	if it.cur = it.next; it.cur == nil {
+		it.val = nil
+		return false
+	}
+
+	it.next, it.val = it.cur.Next(), it.cur.Elt
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *listQueueIterator) Value() adts.ContainerElement {
+	if it.val == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.val
+}
+
+// Err returns adts.ErrConcurrentModification if the queue was changed during
+// iteration.
+func (it *listQueueIterator) Err() error {
+	return it.err
+}
+
+// Remove removes the current element from the queue.
+func (it *listQueueIterator) Remove() bool {
+	if it.lq.threadSafe {
+		it.lq.metrics.Lock(it.lq.lock)
+		defer it.lq.lock.Unlock()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.lq.modCount); it.err != nil {
+		return false
+	}
+
+	// The node goes back to the recycler, so don't keep it.
+	it.lq.backer.Remove(it.cur)
+	it.cur = nil
+	it.lq.modCount++
+	it.expected = it.lq.modCount
+	it.removable = false
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the queue with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (lq *ListQueue) Clone() *ListQueue {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock.RLocker())
+		defer lq.lock.RUnlock()
+		return lq.cloneHelper()
+	}
+
+	return lq.cloneHelper()
+}
+
+// cloneHelper copies the queue element by element without locking.
+func (lq *ListQueue) cloneHelper() *ListQueue {
+	clone := lq.emptyLike()
+	for tmp := lq.backer.Front(); tmp != nil; tmp = tmp.Next() {
+		clone.backer.PushBack(adts.CloneElement(tmp.Elt))
+	}
+
+	return clone
+}
+
+// emptyLike returns an empty queue with the same settings as this one.
+func (lq *ListQueue) emptyLike() *ListQueue {
+	return &ListQueue{nodelist.MakeList(lq.backer.Recycler()), &sync.RWMutex{}, lq.threadSafe, lq.metrics, lq.equal, 0}
+}
+
+// Snapshot returns a copy of the queue's elements from front to back. The
+// elements themselves aren't cloned.
+func (lq *ListQueue) Snapshot() []adts.ContainerElement {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock.RLocker())
+		defer lq.lock.RUnlock()
+		return lq.snapshotHelper()
+	}
+
+	return lq.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (lq *ListQueue) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, lq.backer.Len())
+	for tmp := lq.backer.Front(); tmp != nil; tmp = tmp.Next() {
+		elts = append(elts, tmp.Elt)
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the queue from front to back.
+// The lock (if threadsafe) is only held while the snapshot is copied.
+func (lq *ListQueue) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(lq.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the queue that shares its backing list.
+func (lq *ListQueue) unlocked() *ListQueue {
+	return &ListQueue{lq.backer, lq.lock, false, lq.metrics, lq.equal, lq.modCount}
+}
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the queue. Changes made before fn returns an error
+// are kept.
+func (lq *ListQueue) Update(fn func(tx Queue) error) error {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock)
+		defer lq.lock.Unlock()
+		return lq.updateHelper(fn)
+	}
+
+	return lq.updateHelper(fn)
+}
+
+// updateHelper runs fn against a view of the queue and then copies the view's
+// modification count back.
+func (lq *ListQueue) updateHelper(fn func(tx Queue) error) error {
+	view := lq.unlocked()
+	defer func() {
+		lq.modCount = view.modCount
+	}()
+	return fn(view)
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the queue. fn must not modify the view.
+func (lq *ListQueue) View(fn func(tx Queue) error) error {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock.RLocker())
+		defer lq.lock.RUnlock()
+		return fn(lq.unlocked())
+	}
+
+	return fn(lq.unlocked())
+}
+
+// Atomically runs fn as a single atomic operation on the queue.
+func (lq *ListQueue) Atomically(fn func(tx adts.Container) error) error {
+	return lq.Update(func(tx Queue) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent enqueues the given element only if it isn't already in the
+// queue and returns whether it was added.
+func (lq *ListQueue) AddIfAbsent(item adts.ContainerElement) bool {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock)
+		defer lq.lock.Unlock()
+		return !lq.containsHelper(item) && lq.addHelper(item)
+	}
+
+	return !lq.containsHelper(item) && lq.addHelper(item)
+}
+
+// DequeueIf removes the element from the front of the queue only if it
+// matches the given predicate, and returns the element.
+func (lq *ListQueue) DequeueIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	if lq.threadSafe {
+		lq.metrics.Lock(lq.lock)
+		defer lq.lock.Unlock()
+		return lq.dequeueIfHelper(pred)
+	}
+
+	return lq.dequeueIfHelper(pred)
+}
+
+// dequeueIfHelper peeks at the front of the list and only dequeues it if it matches.
+func (lq *ListQueue) dequeueIfHelper(pred adts.Predicate) (adts.ContainerElement, bool) {
+	if lq.backer.Len() == 0 {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	if !pred(lq.backer.Front().Elt) {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return lq.dequeueHelper()
 }
@@ -99,7 +99,7 @@ func TestListQueueAdd(t *testing.T) {
 
 		iter := queue.backer.Front()
 		for idx, v := range vals {
-			iterVal, ok := iter.Value.(adts.IntElt)
+			iterVal, ok := iter.Elt.(adts.IntElt)
 			if !ok || !iterVal.Equals(adts.IntElt(v)) {
 				t.Errorf("Add failed to add the value to the proper index | (idx,val) - Expected: (%d, %d), Actual: (%d, %v)",
 					idx, v, idx, iterVal)
@@ -145,7 +145,7 @@ func TestListQueueRemove(t *testing.T) {
 		for j := 0; j < int(idx); j++ {
 			tmp = tmp.Next()
 		}
-		val, _ := tmp.Value.(adts.IntElt)
+		val, _ := tmp.Elt.(adts.IntElt)
 		if !queue.Remove(val) {
 			t.Errorf("Failed to remove index %d from list.", idx)
 			return
@@ -198,7 +198,7 @@ func TestListQueueEnqueue(t *testing.T) {
 		}
 		iter := queue.backer.Front()
 		for idx, exp := range expected {
-			act, ok := iter.Value.(adts.IntElt)
+			act, ok := iter.Elt.(adts.IntElt)
 			if !ok || !act.Equals(adts.IntElt(exp)) {
 				t.Errorf("Queue order was ruined by push #%d. (idx, val) - Expected: (%d, %d), Actual: (%d, %v)",
 					i+1, idx, exp, idx, act)
@@ -240,7 +240,7 @@ func listQueueToString(ls *ListQueue) string {
 	res := "["
 	tmp := ls.backer.Front()
 	if tmp != nil {
-		res += fmt.Sprintf("%v", tmp.Value)
+		res += fmt.Sprintf("%v", tmp.Elt)
 		tmp = tmp.Next()
 	}
 
@@ -249,9 +249,323 @@ func listQueueToString(ls *ListQueue) string {
 			break
 		}
 
-		res += fmt.Sprintf(",%v", tmp.Value)
+		res += fmt.Sprintf(",%v", tmp.Elt)
 		tmp = tmp.Next()
 	}
 
 	return res + "]"
 }
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestListQueueDequeueIf(t *testing.T) {
+	var a adts.Atomic
+	a = MakeListQueueThreadSafe()
+
+	queue := a.(*ListQueue)
+	for i := 0; i < 10; i++ {
+		queue.Enqueue(adts.IntElt(i))
+	}
+
+	odd := func(elt adts.ContainerElement) bool {
+		return elt.(adts.IntElt)%2 == 1
+	}
+
+	if _, ok := queue.DequeueIf(odd); ok {
+		t.Error("DequeueIf should not dequeue an element that doesn't match.")
+	}
+	queue.Dequeue()
+	if dequeued, ok := queue.DequeueIf(odd); !ok || !dequeued.Equals(adts.IntElt(1)) {
+		t.Errorf("DequeueIf should dequeue a matching element. Expected: %v, Actual: %v", 1, dequeued)
+	}
+	if queue.Len() != 8 {
+		t.Errorf("Expected length: %d, Actual length: %d", 8, queue.Len())
+	}
+	if queue.AddIfAbsent(adts.IntElt(9)) || !queue.AddIfAbsent(adts.IntElt(20)) {
+		t.Error("AddIfAbsent should only add elements that aren't in the queue.")
+	}
+}
+
+func TestListQueueMoveN(t *testing.T) {
+	retry := MakeListQueueThreadSafe()
+	primary := MakeListQueueThreadSafe()
+	for i := 0; i < 10; i++ {
+		retry.Enqueue(adts.IntElt(i))
+	}
+
+	if err := adts.MoveN(retry, primary, 3); err != nil {
+		t.Errorf("MoveN failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if dequeued, _ := primary.Dequeue(); !dequeued.Equals(adts.IntElt(i)) {
+			t.Errorf("MoveN moved the wrong elements. Expected: %v, Actual: %v", i, dequeued)
+		}
+	}
+	if err := adts.Transfer(retry, primary, adts.IntElt(9)); err != nil || !primary.Contains(adts.IntElt(9)) {
+		t.Errorf("Transfer failed: %v", err)
+	}
+}
+
+func TestListQueueEqualFunc(t *testing.T) {
+	lastDigit := adts.EqualBy(func(e adts.ContainerElement) int {
+		i, _ := e.(adts.IntElt)
+		return int(i) % 10
+	})
+
+	for _, c := range []*ListQueue{MakeListQueue(lastDigit), MakeListQueueThreadSafe(lastDigit)} {
+		c.Enqueue(adts.IntElt(1))
+		c.Enqueue(adts.IntElt(2))
+
+		if !c.Contains(adts.IntElt(11)) || c.Contains(adts.IntElt(3)) {
+			t.Error("Contains should use the EqualFunc.")
+		}
+		if !c.Remove(adts.IntElt(12)) || c.Len() != 1 || c.Contains(adts.IntElt(2)) {
+			t.Error("Remove should use the EqualFunc.")
+		}
+		if c.AddIfAbsent(adts.IntElt(21)) {
+			t.Error("AddIfAbsent should use the EqualFunc.")
+		}
+	}
+}
+
+// testQueueClone checks that clones and snapshots of the given queue are
+// independent of it and ordered from front to back.
+func testQueueClone(t *testing.T, queue Queue, clone func(Queue) Queue) {
+	queue.Enqueue(adts.Bytes{1})
+	queue.Enqueue(adts.IntElt(2))
+
+	c := clone(queue)
+	front, _ := c.Dequeue()
+	front.(adts.Bytes)[0] = 9
+	if queue.Len() != 2 || !queue.Contains(adts.Bytes{1}) {
+		t.Error("Changing the clone changed the original.")
+	}
+
+	snapshot := queue.(adts.Snapshotter).Snapshot()
+	if len(snapshot) != 2 || !snapshot[0].Equals(adts.Bytes{1}) || !snapshot[1].Equals(adts.IntElt(2)) {
+		t.Errorf("Snapshot should be ordered from front to back. Actual: %v", snapshot)
+	}
+
+	it := queue.(adts.Snapshotter).SnapshotIterator()
+	queue.Clear()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("SnapshotIterator saw a later change. Expected: %d, Actual: %d", 2, count)
+	}
+}
+
+func TestListQueueClone(t *testing.T) {
+	testQueueClone(t, MakeListQueueThreadSafe(), func(q Queue) Queue { return q.(*ListQueue).Clone() })
+}
+
+type iterable interface {
+	Iterator() adts.MutableIterator
+}
+
+// testIterator fills c with 0 to 4, checks that its iterator visits them in
+// the given order and can remove the even ones, and that it fails fast.
+func testIterator(t *testing.T, c adts.Container, order []int) {
+	for i := 0; i < 5; i++ {
+		c.Add(adts.IntElt(i))
+	}
+
+	idx := 0
+	it := c.(iterable).Iterator()
+	for it.Next() {
+		if !it.Value().Equals(adts.IntElt(order[idx])) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", order[idx], it.Value())
+		}
+		if order[idx]%2 == 0 && (!it.Remove() || it.Remove()) {
+			t.Error("Remove should succeed once per element.")
+		}
+		idx++
+	}
+	if idx != len(order) || it.Err() != nil {
+		t.Errorf("Iterator should visit every element. Expected: %d, Actual: %d (%v)", len(order), idx, it.Err())
+	}
+	if c.Len() != 2 || !c.Contains(adts.IntElt(1)) || !c.Contains(adts.IntElt(3)) {
+		t.Errorf("Remove should remove the even elements. Expected length: %d, Actual length: %d", 2, c.Len())
+	}
+
+	it = c.(iterable).Iterator()
+	it.Next()
+	c.Add(adts.IntElt(5))
+	expectConcurrentModification(t, it)
+	if it.Remove() {
+		t.Error("Remove should fail after a concurrent modification.")
+	}
+}
+
+// expectConcurrentModification checks that Next fails fast, which panics in
+// debug builds.
+func expectConcurrentModification(t *testing.T, it adts.MutableIterator) {
+	if adts.Debug {
+		defer func() {
+			if r := recover(); r != adts.ErrConcurrentModification {
+				t.Errorf("Next should panic in debug mode. Expected: %v, Actual: %v", adts.ErrConcurrentModification, r)
+			}
+		}()
+	}
+
+	if it.Next() || it.Err() != adts.ErrConcurrentModification {
+		t.Errorf("Next should fail after a modification. Expected: %v, Actual: %v", adts.ErrConcurrentModification, it.Err())
+	}
+}
+
+func TestListQueueIterator(t *testing.T) {
+	testIterator(t, MakeListQueue(), []int{0, 1, 2, 3, 4})
+}
+
+func TestListQueueThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeListQueueThreadSafe(), []int{0, 1, 2, 3, 4})
+}
+
+// makeListQueueOf builds a ListQueue whose elements, in iteration order, are elts.
+func makeListQueueOf(threadSafe bool, elts ...int) *ListQueue {
+	c := MakeListQueue()
+	if threadSafe {
+		c = MakeListQueueThreadSafe()
+	}
+	for _, elt := range elts {
+		c.backer.PushBack(adts.IntElt(elt))
+	}
+
+	return c
+}
+
+// checkListQueue checks that the elements, in iteration order, are exactly expected.
+func checkListQueue(t *testing.T, c *ListQueue, expected ...int) {
+	t.Helper()
+
+	actual := c.Snapshot()
+	if len(actual) != len(expected) {
+		t.Errorf("Wrong elements. Expected: %v, Actual: %v", expected, actual)
+		return
+	}
+	for i, elt := range actual {
+		if !elt.Equals(adts.IntElt(expected[i])) {
+			t.Errorf("Wrong elements. Expected: %v, Actual: %v", expected, actual)
+			return
+		}
+	}
+}
+
+func TestListQueueConcat(t *testing.T) {
+	c := makeListQueueOf(true, 0, 1)
+	other := makeListQueueOf(true, 2, 3)
+
+	if !c.Concat(other) {
+		t.Fatal("Concat should succeed.")
+	}
+	checkListQueue(t, c, 0, 1, 2, 3)
+	checkListQueue(t, other)
+
+	if c.Concat(c) {
+		t.Error("Concat with itself should fail.")
+	}
+}
+
+func TestListQueueSplitAt(t *testing.T) {
+	c := makeListQueueOf(false, 0, 1, 2, 3)
+
+	rest := c.SplitAt(1)
+	checkListQueue(t, c, 0)
+	checkListQueue(t, rest, 1, 2, 3)
+
+	checkListQueue(t, rest.SplitAt(3))
+	checkListQueue(t, rest.SplitAt(0), 1, 2, 3)
+	checkListQueue(t, rest)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SplitAt past the end should panic.")
+		}
+	}()
+	c.SplitAt(2)
+}
+
+func TestListQueueReverseRotate(t *testing.T) {
+	c := makeListQueueOf(true, 0, 1, 2, 3)
+
+	c.Reverse()
+	checkListQueue(t, c, 3, 2, 1, 0)
+
+	c.Rotate(1)
+	checkListQueue(t, c, 2, 1, 0, 3)
+
+	c.Rotate(-5)
+	checkListQueue(t, c, 3, 2, 1, 0)
+
+	empty := makeListQueueOf(false)
+	empty.Reverse()
+	empty.Rotate(2)
+	checkListQueue(t, empty)
+}
+
+func TestListQueueRecyclerAllocs(t *testing.T) {
+	queue := NewListQueue(adts.WithNodeRecycler(adts.MakeNodeRecycler()))
+	var elt adts.ContainerElement = adts.IntElt(1)
+	queue.Enqueue(elt)
+	queue.Dequeue()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		queue.Enqueue(elt)
+		queue.Dequeue()
+	})
+	if allocs != 0 {
+		t.Errorf("Enqueue after Dequeue should reuse the node. Expected: %d, Actual: %v", 0, allocs)
+	}
+}
+
+func TestListQueueIteratorRemoveRecycled(t *testing.T) {
+	r := adts.MakeNodeRecycler()
+	queue := NewListQueue(adts.WithThreadSafety(), adts.WithNodeRecycler(r))
+	queue.Enqueue(adts.IntElt(1))
+	queue.Enqueue(adts.IntElt(1))
+
+	it := queue.Iterator()
+	if !it.Next() || !it.Remove() {
+		t.Fatal("The iterator should remove the first element.")
+	}
+
+	// Another queue may take the removed node straight from the recycler.
+	other := NewListQueue(adts.WithNodeRecycler(r))
+	for i := 0; i < 10; i++ {
+		other.Enqueue(adts.IntElt(2))
+	}
+
+	if !it.Value().Equals(adts.IntElt(1)) {
+		t.Errorf("Value changed after Remove. Expected: %d, Actual: %v", 1, it.Value())
+	}
+}
+
+func BenchmarkListQueueEnqueueDequeue(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []adts.Option
+	}{
+		{"NoRecycler", nil},
+		{"Recycler", []adts.Option{adts.WithNodeRecycler(adts.MakeNodeRecycler())}},
+		{"ThreadSafeRecycler", []adts.Option{adts.WithThreadSafety(), adts.WithNodeRecycler(adts.MakeNodeRecycler())}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			queue := NewListQueue(bc.opts...)
+			var elt adts.ContainerElement = adts.IntElt(1)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 64; j++ {
+					queue.Enqueue(elt)
+				}
+				for j := 0; j < 64; j++ {
+					queue.Dequeue()
+				}
+			}
+		})
+	}
+}
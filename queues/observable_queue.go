@@ -0,0 +1,111 @@
+package queueadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// ObservableQueue wraps any Queue and reports every change made through it to the
+// registered listeners. It has the same ordering guarantees as
+// adts.ObservableContainer: changes are serialized by the wrapper and
+// listeners are called synchronously, in order, before the changing method
+// returns.
+type ObservableQueue struct {
+	inner Queue
+	lock  *sync.Mutex
+
+	*adts.Notifier
+}
+
+// ObserveQueue wraps the given queue so that its changes can be observed.
+func ObserveQueue(q Queue) *ObservableQueue {
+	return &ObservableQueue{q, &sync.Mutex{}, adts.MakeNotifier()}
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the queue.
+func (o *ObservableQueue) Len() int {
+	return o.inner.Len()
+}
+
+// IsEmpty returns if the queue is empty or not.
+func (o *ObservableQueue) IsEmpty() bool {
+	return o.inner.IsEmpty()
+}
+
+// Clear removes all elements from the queue.
+func (o *ObservableQueue) Clear() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.inner.Clear()
+	o.Notify(adts.Event{Kind: adts.Cleared, Index: -1})
+}
+
+// Contains returns true if the given item is in the queue.
+func (o *ObservableQueue) Contains(item adts.ContainerElement) bool {
+	return o.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the queue.
+func (o *ObservableQueue) Add(item adts.ContainerElement) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.inner.Add(item) {
+		return false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Added, Elt: item, Index: -1})
+	return true
+}
+
+// Remove returns true if the given element was removed.
+func (o *ObservableQueue) Remove(item adts.ContainerElement) bool {
+	_, ok := o.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (o *ObservableQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	elt, ok := adts.RemoveElement(o.inner, item)
+	if !ok {
+		return elt, false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Removed, Elt: elt, Index: -1})
+	return elt, true
+}
+
+// -------------------------------------------------------
+// Queue Methods
+// -------------------------------------------------------
+
+// Enqueue pushes the given element onto the back of the queue.
+func (o *ObservableQueue) Enqueue(item adts.ContainerElement) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.inner.Enqueue(item) {
+		return false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Enqueued, Elt: item, Index: -1})
+	return true
+}
+
+// Dequeue removes the element from the front of the queue and returns the element.
+func (o *ObservableQueue) Dequeue() (adts.ContainerElement, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	dequeued, ok := o.inner.Dequeue()
+	if ok {
+		o.Notify(adts.Event{Kind: adts.Dequeued, Elt: dequeued, Index: -1})
+	}
+
+	return dequeued, ok
+}
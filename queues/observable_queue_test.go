@@ -0,0 +1,61 @@
+package queueadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestObserveQueue(t *testing.T) {
+	var q Queue
+	oq := ObserveQueue(MakeSliceQueue())
+	q = oq
+
+	events := []adts.Event{}
+	oq.Subscribe(func(e adts.Event) {
+		events = append(events, e)
+	})
+
+	q.Enqueue(adts.IntElt(1))
+	q.Enqueue(adts.IntElt(2))
+	q.Dequeue()
+
+	expected := []adts.Event{
+		{Kind: adts.Enqueued, Elt: adts.IntElt(1), Index: -1},
+		{Kind: adts.Enqueued, Elt: adts.IntElt(2), Index: -1},
+		{Kind: adts.Dequeued, Elt: adts.IntElt(1), Index: -1},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, Actual: %d", len(expected), len(events))
+	}
+	for idx, e := range expected {
+		if events[idx] != e {
+			t.Errorf("Wrong event #%d. Expected: %v, Actual: %v", idx, e, events[idx])
+		}
+	}
+}
+
+func TestObserveQueueRemoveReportsRemovedElement(t *testing.T) {
+	lastDigit := adts.EqualBy(func(e adts.ContainerElement) int {
+		i, _ := e.(adts.IntElt)
+		return int(i) % 10
+	})
+
+	for _, c := range []Queue{
+		MakeSliceQueue(lastDigit),
+		MakeListQueue(lastDigit),
+		MakeSegmentedQueue(lastDigit),
+		MakeTwoLockQueue(lastDigit),
+	} {
+		o := ObserveQueue(SynchronizedQueue(InstrumentQueue(c, adts.MakeMetrics())))
+		var removed adts.ContainerElement
+		o.Subscribe(func(e adts.Event) {
+			removed = e.Elt
+		})
+
+		o.Add(adts.IntElt(11))
+		if !o.Remove(adts.IntElt(21)) || removed == nil || !removed.Equals(adts.IntElt(11)) {
+			t.Errorf("%T: the event should carry the removed element. Expected: %v, Actual: %v", c, 11, removed)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package queueadts
+
+import (
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// consNode is a cell of the immutable linked lists behind a PersistentQueue.
+// Nodes are never changed once they're created, so any number of queues can
+// share them.
+type consNode struct {
+	elt  adts.ContainerElement
+	next *consNode
+}
+
+// PersistentQueue is an immutable queue. Enqueue and Dequeue return a new
+// queue and leave the original unchanged, so old versions stay valid. It's
+// safe to share between goroutines without locking.
+//
+// It's a banker's queue: elements are dequeued from the front list and
+// enqueued onto the rear list, which is kept in reverse. Whenever the rear
+// grows longer than the front, the rear is reversed onto the end of the
+// front. Enqueue and Dequeue are amortized O(1) when each version is used
+// once; repeatedly dequeuing from the same old version can repeat a reversal.
+type PersistentQueue struct {
+	front    *consNode
+	frontLen int
+	rear     *consNode
+	rearLen  int
+	equal    adts.EqualFunc
+}
+
+// NewPersistentQueue creates an empty PersistentQueue configured by the given
+// options. Only the equality option applies; a PersistentQueue never needs
+// locking.
+func NewPersistentQueue(opts ...adts.Option) *PersistentQueue {
+	o := adts.MakeOptions(opts...)
+	return &PersistentQueue{nil, 0, nil, 0, o.Equal}
+}
+
+// MakePersistentQueue creates an empty PersistentQueue. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the queue.
+func MakePersistentQueue(eq ...adts.EqualFunc) *PersistentQueue {
+	return NewPersistentQueue(adts.WithEquality(firstEqual(eq)))
+}
+
+// makeBalanced returns a queue with the given lists, moving the rear onto the
+// front if the rear is longer.
+func makeBalanced(front *consNode, frontLen int, rear *consNode, rearLen int, equal adts.EqualFunc) *PersistentQueue {
+	if rearLen <= frontLen {
+		return &PersistentQueue{front, frontLen, rear, rearLen, equal}
+	}
+
+	// front ++ reverse(rear). The front has to be copied since its last node
+	// is shared with older versions.
+	var elts []adts.ContainerElement
+	for tmp := front; tmp != nil; tmp = tmp.next {
+		elts = append(elts, tmp.elt)
+	}
+
+	var newFront *consNode
+	for tmp := rear; tmp != nil; tmp = tmp.next {
+		newFront = &consNode{tmp.elt, newFront}
+	}
+	for i := len(elts) - 1; i >= 0; i-- {
+		newFront = &consNode{elts[i], newFront}
+	}
+
+	return &PersistentQueue{newFront, frontLen + rearLen, nil, 0, equal}
+}
+
+// Len returns the number of elements in the queue.
+func (pq *PersistentQueue) Len() int {
+	return pq.frontLen + pq.rearLen
+}
+
+// IsEmpty returns if the queue is empty or not.
+func (pq *PersistentQueue) IsEmpty() bool {
+	return pq.Len() == 0
+}
+
+// Contains returns true if the given item is in the queue.
+func (pq *PersistentQueue) Contains(item adts.ContainerElement) bool {
+	for _, list := range []*consNode{pq.front, pq.rear} {
+		for tmp := list; tmp != nil; tmp = tmp.next {
+			if pq.equal.Equal(tmp.elt, item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Peek returns the element at the front of the queue without removing it.
+func (pq *PersistentQueue) Peek() (adts.ContainerElement, bool) {
+	if pq.front == nil {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return pq.front.elt, true
+}
+
+// Enqueue returns a new queue with the given element added to the back.
+func (pq *PersistentQueue) Enqueue(item adts.ContainerElement) *PersistentQueue {
+	return makeBalanced(pq.front, pq.frontLen, &consNode{item, pq.rear}, pq.rearLen+1, pq.equal)
+}
+
+// Dequeue returns the element at the front of the queue and a new queue
+// without it. Dequeuing an empty queue returns the queue itself and false.
+func (pq *PersistentQueue) Dequeue() (adts.ContainerElement, *PersistentQueue, bool) {
+	// The rear is never longer than the front, so an empty front means an
+	// empty queue.
+	if pq.front == nil {
+		return adts.EmptyContainerElement{}, pq, false
+	}
+
+	return pq.front.elt, makeBalanced(pq.front.next, pq.frontLen-1, pq.rear, pq.rearLen, pq.equal), true
+}
+
+// Remove returns a new queue without the first element (in dequeue order)
+// equal to the given item, and whether an element was removed. It copies
+// the whole queue.
+func (pq *PersistentQueue) Remove(item adts.ContainerElement) (*PersistentQueue, bool) {
+	elts := pq.elements()
+	for idx, elt := range elts {
+		if pq.equal.Equal(elt, item) {
+			return persistentQueueOf(append(elts[:idx], elts[idx+1:]...), pq.equal), true
+		}
+	}
+
+	return pq, false
+}
+
+// Clear returns an empty queue that uses the same equality as this one.
+func (pq *PersistentQueue) Clear() *PersistentQueue {
+	return &PersistentQueue{nil, 0, nil, 0, pq.equal}
+}
+
+// elements returns the elements of the queue in dequeue order.
+func (pq *PersistentQueue) elements() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, pq.Len())
+	for tmp := pq.front; tmp != nil; tmp = tmp.next {
+		elts = append(elts, tmp.elt)
+	}
+
+	start := len(elts)
+	for tmp := pq.rear; tmp != nil; tmp = tmp.next {
+		elts = append(elts, tmp.elt)
+	}
+	for i, j := start, len(elts)-1; i < j; i, j = i+1, j-1 {
+		elts[i], elts[j] = elts[j], elts[i]
+	}
+
+	return elts
+}
+
+// persistentQueueOf returns a queue holding the given elements in dequeue
+// order, all in the front list.
+func persistentQueueOf(elts []adts.ContainerElement, equal adts.EqualFunc) *PersistentQueue {
+	var front *consNode
+	for i := len(elts) - 1; i >= 0; i-- {
+		front = &consNode{elts[i], front}
+	}
+
+	return &PersistentQueue{front, len(elts), nil, 0, equal}
+}
+
+// ToListQueue returns a new non-threadsafe ListQueue with the same elements
+// in the same order.
+func (pq *PersistentQueue) ToListQueue() *ListQueue {
+	lq := NewListQueue(adts.WithEquality(pq.equal))
+	for _, elt := range pq.elements() {
+		lq.backer.PushBack(elt)
+	}
+
+	return lq
+}
+
+// Persistent returns a PersistentQueue holding the queue's current elements
+// in the same order. The queue is read under its read lock (if threadsafe).
+func (lq *ListQueue) Persistent() *PersistentQueue {
+	var elts []adts.ContainerElement
+	lq.View(func(tx Queue) error {
+		view := tx.(*ListQueue)
+		for tmp := view.backer.Front(); tmp != nil; tmp = tmp.Next() {
+			elts = append(elts, tmp.Elt)
+		}
+		return nil
+	})
+
+	return persistentQueueOf(elts, lq.equal)
+}
@@ -0,0 +1,106 @@
+package queueadts
+
+import (
+	"math/rand"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestPersistentQueueEnqueueDequeue(t *testing.T) {
+	empty := MakePersistentQueue()
+	if _, rest, ok := empty.Dequeue(); ok || rest != empty {
+		t.Error("Dequeue on an empty queue should fail.")
+	}
+
+	q := empty
+	for i := 0; i < 10; i++ {
+		q = q.Enqueue(adts.IntElt(i))
+	}
+
+	old := q
+	for i := 0; i < 10; i++ {
+		elt, rest, ok := q.Dequeue()
+		if !ok || !elt.Equals(adts.IntElt(i)) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", i, elt)
+		}
+		q = rest
+	}
+
+	if !q.IsEmpty() || old.Len() != 10 || !empty.IsEmpty() {
+		t.Error("Dequeuing changed an older version.")
+	}
+	if front, _ := old.Peek(); !front.Equals(adts.IntElt(0)) {
+		t.Errorf("Wrong front. Expected: %d, Actual: %v", 0, front)
+	}
+}
+
+func TestPersistentQueueRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(99))
+
+	versions := []*PersistentQueue{MakePersistentQueue()}
+	models := [][]adts.ContainerElement{nil}
+
+	for i := 0; i < 2000; i++ {
+		v := r.Intn(len(versions))
+		pq, model := versions[v], models[v]
+
+		switch r.Intn(4) {
+		case 0, 1:
+			pq = pq.Enqueue(adts.IntElt(i))
+			model = append(append([]adts.ContainerElement{}, model...), adts.IntElt(i))
+		case 2:
+			if elt, rest, ok := pq.Dequeue(); ok {
+				if !elt.Equals(model[0]) {
+					t.Fatalf("Wrong element. Expected: %v, Actual: %v", model[0], elt)
+				}
+				pq, model = rest, model[1:]
+			}
+		case 3:
+			if len(model) > 0 {
+				idx := r.Intn(len(model))
+				rest, removed := pq.Remove(model[idx])
+				if !removed {
+					t.Fatalf("Remove of %v failed.", model[idx])
+				}
+				pq = rest
+				model = append(append([]adts.ContainerElement{}, model[:idx]...), model[idx+1:]...)
+			}
+		}
+
+		versions = append(versions, pq)
+		models = append(models, model)
+	}
+
+	for v, pq := range versions {
+		elts := pq.elements()
+		if len(elts) != len(models[v]) || pq.Len() != len(models[v]) {
+			t.Fatalf("Version %d has the wrong length. Expected: %d, Actual: %d", v, len(models[v]), len(elts))
+		}
+		for i := range elts {
+			if !elts[i].Equals(models[v][i]) {
+				t.Fatalf("Version %d is wrong at %d. Expected: %v, Actual: %v", v, i, models[v][i], elts[i])
+			}
+		}
+	}
+}
+
+func TestPersistentQueueConversion(t *testing.T) {
+	lq := MakeListQueueThreadSafe()
+	for i := 0; i < 5; i++ {
+		lq.Enqueue(adts.IntElt(i))
+	}
+
+	pq := lq.Persistent()
+	lq.Dequeue()
+	if pq.Len() != 5 || !pq.Contains(adts.IntElt(0)) {
+		t.Error("Changing the ListQueue changed its persistent copy.")
+	}
+
+	back := pq.Enqueue(adts.IntElt(5)).ToListQueue()
+	for i := 0; i < 6; i++ {
+		if elt, ok := back.Dequeue(); !ok || !elt.Equals(adts.IntElt(i)) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", i, elt)
+		}
+	}
+}
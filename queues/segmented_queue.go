@@ -0,0 +1,600 @@
+package queueadts
+
+import (
+	"sync"
+	"sync/atomic"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// DefaultSegmentSize is the number of elements each segment of a
+// SegmentedQueue holds unless adts.WithNodeCapacity says otherwise.
+const DefaultSegmentSize = 256
+
+// segment is a fixed-size chunk of a SegmentedQueue. written counts the
+// slots the enqueuer has filled and next links the following segment; both
+// are atomic because the head and tail sides read them under different
+// locks.
+type segment struct {
+	elts    []adts.ContainerElement
+	written atomic.Int32
+	next    atomic.Pointer[segment]
+}
+
+// segmentChain is the state of a SegmentedQueue. It's shared by the queue
+// and its unlocked views. The head side (head, headIdx) is only touched by
+// dequeuers and the tail side (tail) only by enqueuers. modCount is atomic
+// because both sides change it, each under its own lock.
+type segmentChain struct {
+	head     *segment
+	headIdx  int
+	tail     *segment
+	spare    atomic.Pointer[segment]
+	len      atomic.Int64
+	modCount atomic.Uint64
+	size     int
+}
+
+// SegmentedQueue is a FIFO queue stored in a linked list of fixed-size
+// segments. Enqueue and Dequeue take O(1) time, growing never copies
+// elements, and a fully dequeued segment is kept as a spare for the next
+// one the queue needs, so at most about three segments of slots are unused
+// at any time.
+//
+// The threadsafe variant has separate head and tail locks, so an enqueuer
+// and a dequeuer never wait on each other. Len is read atomically without
+// locking. Operations that look at the whole queue (Contains, Remove, Clear,
+// the snapshot and atomic methods) take both locks, head lock first.
+type SegmentedQueue struct {
+	chain      *segmentChain
+	headLock   *sync.Mutex
+	tailLock   *sync.Mutex
+	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+}
+
+// NewSegmentedQueue creates a new SegmentedQueue configured by the given
+// options. NodeCapacity sets the number of elements per segment. Capacity
+// and ShrinkFactor don't apply and are ignored.
+func NewSegmentedQueue(opts ...adts.Option) *SegmentedQueue {
+	o := adts.MakeOptions(opts...)
+
+	size := o.NodeCapacity
+	if size == 0 {
+		size = DefaultSegmentSize
+	}
+
+	chain := &segmentChain{size: size}
+	chain.head = chain.newSegment()
+	chain.tail = chain.head
+
+	return &SegmentedQueue{chain, &sync.Mutex{}, &sync.Mutex{}, o.ThreadSafe, o.Metrics, o.Equal}
+}
+
+// MakeSegmentedQueue creates a new non-threadsafe SegmentedQueue. An
+// optional EqualFunc replaces ContainerElement.Equals when searching the
+// queue.
+func MakeSegmentedQueue(eq ...adts.EqualFunc) *SegmentedQueue {
+	return NewSegmentedQueue(adts.WithEquality(firstEqual(eq)))
+}
+
+// MakeSegmentedQueueThreadSafe creates a new threadsafe SegmentedQueue. An
+// optional EqualFunc replaces ContainerElement.Equals when searching the
+// queue.
+func MakeSegmentedQueueThreadSafe(eq ...adts.EqualFunc) *SegmentedQueue {
+	return NewSegmentedQueue(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the queue, which then records how
+// long it waits on its locks. It must be called before the queue is shared
+// between goroutines.
+func (sq *SegmentedQueue) SetMetrics(m *adts.Metrics) {
+	sq.metrics = m
+}
+
+// lockBoth takes the head lock and then the tail lock.
+func (sq *SegmentedQueue) lockBoth() {
+	sq.metrics.Lock(sq.headLock)
+	sq.metrics.Lock(sq.tailLock)
+}
+
+// unlockBoth releases the locks taken by lockBoth.
+func (sq *SegmentedQueue) unlockBoth() {
+	sq.tailLock.Unlock()
+	sq.headLock.Unlock()
+}
+
+// -------------------------------------------------------
+// Segment Methods
+// -------------------------------------------------------
+
+// newSegment returns the spare segment if there is one, or a new one.
+func (c *segmentChain) newSegment() *segment {
+	if seg := c.spare.Swap(nil); seg != nil {
+		return seg
+	}
+
+	return &segment{elts: make([]adts.ContainerElement, c.size)}
+}
+
+// recycle empties a segment that's no longer linked in and keeps it as the
+// spare.
+func (c *segmentChain) recycle(seg *segment) {
+	clear(seg.elts)
+	seg.written.Store(0)
+	seg.next.Store(nil)
+	c.spare.Store(seg)
+}
+
+// enqueue adds the element after the last one. Only the tail side is
+// touched, and the element is written before it's published.
+func (c *segmentChain) enqueue(item adts.ContainerElement) {
+	// Counting first means Len never goes negative when a dequeuer takes
+	// the element before this returns.
+	c.len.Add(1)
+	c.modCount.Add(1)
+
+	tail := c.tail
+	n := tail.written.Load()
+	if int(n) < c.size {
+		tail.elts[n] = item
+		tail.written.Store(n + 1)
+		return
+	}
+
+	seg := c.newSegment()
+	seg.elts[0] = item
+	seg.written.Store(1)
+	c.tail = seg
+	tail.next.Store(seg)
+}
+
+// front returns the head segment moved past a fully dequeued segment if
+// there's a next one, recycling the old one.
+func (c *segmentChain) front() *segment {
+	if c.headIdx == c.size {
+		if next := c.head.next.Load(); next != nil {
+			old := c.head
+			c.head, c.headIdx = next, 0
+			c.recycle(old)
+		}
+	}
+
+	return c.head
+}
+
+// peek returns the first element without removing it. Only the head side
+// is touched.
+func (c *segmentChain) peek() (adts.ContainerElement, bool) {
+	head := c.front()
+	if c.headIdx >= int(head.written.Load()) {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return head.elts[c.headIdx], true
+}
+
+// dequeue removes and returns the first element. Only the head side is
+// touched.
+func (c *segmentChain) dequeue() (adts.ContainerElement, bool) {
+	elt, ok := c.peek()
+	if !ok {
+		return elt, false
+	}
+
+	c.head.elts[c.headIdx] = nil
+	c.headIdx++
+	c.len.Add(-1)
+	c.modCount.Add(1)
+	return elt, true
+}
+
+// each calls fn with a pointer to every element slot from front to back
+// until it returns false. Both locks must be held.
+func (c *segmentChain) each(fn func(slot *adts.ContainerElement) bool) {
+	i := c.headIdx
+	for seg := c.head; seg != nil; seg = seg.next.Load() {
+		for n := int(seg.written.Load()); i < n; i++ {
+			if !fn(&seg.elts[i]) {
+				return
+			}
+		}
+		i = 0
+	}
+}
+
+// remove removes the first element equal to item and returns it. Both
+// locks must be held.
+func (c *segmentChain) remove(item adts.ContainerElement, eq adts.EqualFunc) (adts.ContainerElement, bool) {
+	return c.removeFirst(func(slot *adts.ContainerElement) bool {
+		return eq.Equal(*slot, item)
+	})
+}
+
+// removeFirst removes the element in the first slot that matches by
+// shifting every later element back a slot, and returns it. Both locks must
+// be held.
+func (c *segmentChain) removeFirst(match func(slot *adts.ContainerElement) bool) (adts.ContainerElement, bool) {
+	var removed adts.ContainerElement
+	var prev *adts.ContainerElement
+	c.each(func(slot *adts.ContainerElement) bool {
+		if prev != nil {
+			*prev = *slot
+			prev = slot
+		} else if match(slot) {
+			removed, prev = *slot, slot
+		}
+		return true
+	})
+	if prev == nil {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	// prev is now the last slot. It's either in the tail or, if the tail is
+	// still empty, in the full segment before it.
+	*prev = nil
+	if n := c.tail.written.Load(); n > 0 {
+		c.tail.written.Store(n - 1)
+	} else {
+		last := c.head
+		for last.next.Load() != c.tail {
+			last = last.next.Load()
+		}
+		last.written.Store(int32(c.size - 1))
+		last.next.Store(nil)
+		c.recycle(c.tail)
+		c.tail = last
+	}
+	c.len.Add(-1)
+	c.modCount.Add(1)
+
+	return removed, true
+}
+
+// reset drops every element, keeping the head segment. Both locks must be
+// held.
+func (c *segmentChain) reset() {
+	head := c.head
+	clear(head.elts)
+	head.written.Store(0)
+	head.next.Store(nil)
+	c.headIdx = 0
+	c.tail = head
+	c.len.Store(0)
+	c.modCount.Add(1)
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the queue. It doesn't lock.
+func (sq *SegmentedQueue) Len() int {
+	return int(sq.chain.len.Load())
+}
+
+// IsEmpty returns if the queue is empty or not.
+func (sq *SegmentedQueue) IsEmpty() bool {
+	return sq.Len() == 0
+}
+
+// Clear removes all elements from the queue.
+func (sq *SegmentedQueue) Clear() {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		sq.chain.reset()
+		return
+	}
+
+	sq.chain.reset()
+}
+
+// Contains returns true if the given item is in the queue.
+func (sq *SegmentedQueue) Contains(item adts.ContainerElement) bool {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		return sq.containsHelper(item)
+	}
+
+	return sq.containsHelper(item)
+}
+
+// containsHelper checks the segments for the given element (in a
+// non-threadsafe way).
+func (sq *SegmentedQueue) containsHelper(item adts.ContainerElement) bool {
+	found := false
+	sq.chain.each(func(slot *adts.ContainerElement) bool {
+		found = sq.equal.Equal(*slot, item)
+		return !found
+	})
+
+	return found
+}
+
+// Add returns true if the given element was added to the back of the queue.
+func (sq *SegmentedQueue) Add(item adts.ContainerElement) bool {
+	if sq.threadSafe {
+		sq.metrics.Lock(sq.tailLock)
+		defer sq.tailLock.Unlock()
+		sq.chain.enqueue(item)
+		return true
+	}
+
+	sq.chain.enqueue(item)
+	return true
+}
+
+// Remove returns true if the given element was removed. Later elements are
+// shifted forward, so it takes O(n) time.
+func (sq *SegmentedQueue) Remove(item adts.ContainerElement) bool {
+	_, ok := sq.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (sq *SegmentedQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		return sq.chain.remove(item, sq.equal)
+	}
+
+	return sq.chain.remove(item, sq.equal)
+}
+
+// -------------------------------------------------------
+// Queue Methods
+// -------------------------------------------------------
+
+// Enqueue pushes the given element onto the back of the queue.
+func (sq *SegmentedQueue) Enqueue(item adts.ContainerElement) bool {
+	return sq.Add(item)
+}
+
+// Dequeue removes the element from the front of the queue and returns the element.
+func (sq *SegmentedQueue) Dequeue() (adts.ContainerElement, bool) {
+	if sq.threadSafe {
+		sq.metrics.Lock(sq.headLock)
+		defer sq.headLock.Unlock()
+		return sq.chain.dequeue()
+	}
+
+	return sq.chain.dequeue()
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the queue from front to back. Its Err
+// returns adts.ErrConcurrentModification if the queue is changed other than
+// through the iterator's Remove, including by an Enqueue or Dequeue. Next and
+// Remove take both locks (if threadsafe).
+func (sq *SegmentedQueue) Iterator() adts.MutableIterator {
+	return &segmentedQueueIterator{sq: sq, expected: sq.chain.modCount.Load()}
+}
+
+// segmentedQueueIterator is the fail-fast iterator of a SegmentedQueue. It
+// keeps the segment and index of the current element. Remove shifts the
+// later elements back, so after a removal the next element is in the
+// current slot.
+type segmentedQueueIterator struct {
+	sq        *SegmentedQueue
+	seg       *segment
+	idx       int
+	val       adts.ContainerElement
+	started   bool
+	removed   bool
+	expected  uint64
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the queue has been changed.
+func (it *segmentedQueueIterator) Next() bool {
+	if it.sq.threadSafe {
+		it.sq.lockBoth()
+		defer it.sq.unlockBoth()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.sq.chain.modCount.Load()); it.err != nil {
+		return false
+	}
+
+	switch {
+	case !it.started:
+		it.seg, it.idx, it.started = it.sq.chain.head, it.sq.chain.headIdx, true
+	case it.removed:
+		it.removed = false
+	case it.seg != nil:
+		it.idx++
+	}
+	for it.seg != nil && it.idx >= int(it.seg.written.Load()) {
+		it.seg, it.idx = it.seg.next.Load(), 0
+	}
+
+	if it.seg == nil {
+		it.val = nil
+		return false
+	}
+
+	it.val = it.seg.elts[it.idx]
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *segmentedQueueIterator) Value() adts.ContainerElement {
+	if it.val == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.val
+}
+
+// Err returns adts.ErrConcurrentModification if the queue was changed during
+// iteration.
+func (it *segmentedQueueIterator) Err() error {
+	return it.err
+}
+
+// Remove removes the current element from the queue. Later elements are
+// shifted forward, so it takes O(n) time.
+func (it *segmentedQueueIterator) Remove() bool {
+	if it.sq.threadSafe {
+		it.sq.lockBoth()
+		defer it.sq.unlockBoth()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.sq.chain.modCount.Load()); it.err != nil {
+		return false
+	}
+
+	cur := &it.seg.elts[it.idx]
+	it.sq.chain.removeFirst(func(slot *adts.ContainerElement) bool {
+		return slot == cur
+	})
+	it.expected = it.sq.chain.modCount.Load()
+	it.removable = false
+	it.removed = true
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the queue with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (sq *SegmentedQueue) Clone() *SegmentedQueue {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		return sq.cloneHelper()
+	}
+
+	return sq.cloneHelper()
+}
+
+// cloneHelper copies the queue element by element without locking.
+func (sq *SegmentedQueue) cloneHelper() *SegmentedQueue {
+	clone := NewSegmentedQueue(adts.WithNodeCapacity(sq.chain.size))
+	clone.threadSafe, clone.metrics, clone.equal = sq.threadSafe, sq.metrics, sq.equal
+	sq.chain.each(func(slot *adts.ContainerElement) bool {
+		clone.chain.enqueue(adts.CloneElement(*slot))
+		return true
+	})
+
+	return clone
+}
+
+// Snapshot returns a copy of the queue's elements from front to back. The
+// elements themselves aren't cloned.
+func (sq *SegmentedQueue) Snapshot() []adts.ContainerElement {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		return sq.snapshotHelper()
+	}
+
+	return sq.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (sq *SegmentedQueue) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, sq.chain.len.Load())
+	sq.chain.each(func(slot *adts.ContainerElement) bool {
+		elts = append(elts, *slot)
+		return true
+	})
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the queue from front to back.
+// The locks (if threadsafe) are only held while the snapshot is copied.
+func (sq *SegmentedQueue) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(sq.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the queue that shares its segments.
+func (sq *SegmentedQueue) unlocked() *SegmentedQueue {
+	return &SegmentedQueue{sq.chain, sq.headLock, sq.tailLock, false, sq.metrics, sq.equal}
+}
+
+// Update runs fn while holding both locks (if threadsafe), passing it a
+// non-threadsafe view of the queue. Changes made before fn returns an error
+// are kept.
+func (sq *SegmentedQueue) Update(fn func(tx Queue) error) error {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		return fn(sq.unlocked())
+	}
+
+	return fn(sq.unlocked())
+}
+
+// View runs fn while holding both locks (if threadsafe), passing it a
+// non-threadsafe view of the queue. fn must not modify the view.
+func (sq *SegmentedQueue) View(fn func(tx Queue) error) error {
+	return sq.Update(fn)
+}
+
+// Atomically runs fn as a single atomic operation on the queue.
+func (sq *SegmentedQueue) Atomically(fn func(tx adts.Container) error) error {
+	return sq.Update(func(tx Queue) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent enqueues the given element only if it isn't already in the
+// queue and returns whether it was added.
+func (sq *SegmentedQueue) AddIfAbsent(item adts.ContainerElement) bool {
+	if sq.threadSafe {
+		sq.lockBoth()
+		defer sq.unlockBoth()
+		return !sq.containsHelper(item) && sq.unlocked().Add(item)
+	}
+
+	return !sq.containsHelper(item) && sq.Add(item)
+}
+
+// DequeueIf removes the element from the front of the queue only if it
+// matches the given predicate, and returns the element. It only takes the
+// head lock.
+func (sq *SegmentedQueue) DequeueIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	if sq.threadSafe {
+		sq.metrics.Lock(sq.headLock)
+		defer sq.headLock.Unlock()
+		return sq.dequeueIfHelper(pred)
+	}
+
+	return sq.dequeueIfHelper(pred)
+}
+
+// dequeueIfHelper peeks at the front of the queue and only dequeues it if it matches.
+func (sq *SegmentedQueue) dequeueIfHelper(pred adts.Predicate) (adts.ContainerElement, bool) {
+	elt, ok := sq.chain.peek()
+	if !ok || !pred(elt) {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return sq.chain.dequeue()
+}
@@ -0,0 +1,276 @@
+package queueadts
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// checkSegmentedQueue checks the queue holds exactly the given ints, front
+// first, and that only the tail segment is partly filled.
+func checkSegmentedQueue(t *testing.T, sq *SegmentedQueue, expected ...int) {
+	t.Helper()
+
+	actual := sq.Snapshot()
+	if len(actual) != len(expected) || sq.Len() != len(expected) {
+		t.Fatalf("Wrong length. Expected: %d, Actual: %d (Len %d)", len(expected), len(actual), sq.Len())
+	}
+	for i, elt := range actual {
+		if !elt.Equals(adts.IntElt(expected[i])) {
+			t.Fatalf("Wrong element at %d. Expected: %v, Actual: %v", i, expected, actual)
+		}
+	}
+
+	c := sq.chain
+	for seg := c.head; seg != c.tail; seg = seg.next.Load() {
+		if int(seg.written.Load()) != c.size {
+			t.Fatalf("Only the tail segment should be partly filled. Expected: %d, Actual: %d", c.size, seg.written.Load())
+		}
+	}
+	if c.tail.next.Load() != nil {
+		t.Fatal("The tail segment shouldn't have a next segment.")
+	}
+}
+
+// makeSegmentedQueueOf returns a queue with the given segment size holding
+// the given ints.
+func makeSegmentedQueueOf(size int, elts ...int) *SegmentedQueue {
+	sq := NewSegmentedQueue(adts.WithNodeCapacity(size))
+	for _, elt := range elts {
+		sq.Enqueue(adts.IntElt(elt))
+	}
+
+	return sq
+}
+
+func TestMakeSegmentedQueue(t *testing.T) {
+	sq := MakeSegmentedQueue()
+	if sq.threadSafe || !sq.IsEmpty() || sq.chain.size != DefaultSegmentSize {
+		t.Errorf("Unexpected new queue. Expected segment size: %d, Actual: %d", DefaultSegmentSize, sq.chain.size)
+	}
+
+	if sq := MakeSegmentedQueueThreadSafe(); !sq.threadSafe {
+		t.Error("Threadsafe should be true for call to threadsafe make.")
+	}
+	if sq := NewSegmentedQueue(adts.WithNodeCapacity(4)); sq.chain.size != 4 {
+		t.Errorf("NewSegmentedQueue didn't apply its options. Expected segment size: %d, Actual: %d", 4, sq.chain.size)
+	}
+}
+
+func TestSegmentedQueueEnqueueDequeue(t *testing.T) {
+	sq := makeSegmentedQueueOf(3, 0, 1, 2, 3, 4, 5, 6)
+	checkSegmentedQueue(t, sq, 0, 1, 2, 3, 4, 5, 6)
+
+	for i := 0; i < 7; i++ {
+		if elt, ok := sq.Dequeue(); !ok || !elt.Equals(adts.IntElt(i)) {
+			t.Fatalf("Dequeue returned the wrong element. Expected: %d, Actual: %v", i, elt)
+		}
+	}
+	if _, ok := sq.Dequeue(); ok {
+		t.Error("Dequeue on an empty queue should fail.")
+	}
+	checkSegmentedQueue(t, sq)
+
+	sq.Enqueue(adts.IntElt(7))
+	checkSegmentedQueue(t, sq, 7)
+}
+
+func TestSegmentedQueueRecyclesSegments(t *testing.T) {
+	sq := makeSegmentedQueueOf(4, 0, 1, 2, 3, 4)
+	first := sq.chain.head
+	for i := 0; i < 5; i++ {
+		sq.Dequeue()
+	}
+	if sq.chain.spare.Load() != first {
+		t.Fatal("A dequeued segment should be kept as the spare.")
+	}
+
+	var elt adts.ContainerElement = adts.IntElt(0)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 4; i++ {
+			sq.Enqueue(elt)
+		}
+		for i := 0; i < 4; i++ {
+			sq.Dequeue()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("A queue that holds a segment at a time shouldn't allocate. Expected: %d, Actual: %v", 0, allocs)
+	}
+}
+
+func TestSegmentedQueueRemove(t *testing.T) {
+	sq := makeSegmentedQueueOf(3, 0, 1, 2, 3, 4, 5, 6)
+	sq.Dequeue()
+
+	if !sq.Remove(adts.IntElt(2)) || sq.Remove(adts.IntElt(2)) {
+		t.Error("Remove should remove an element once.")
+	}
+	checkSegmentedQueue(t, sq, 1, 3, 4, 5, 6)
+
+	// The tail segment is empty now, so the tail moves back a segment.
+	if !sq.Remove(adts.IntElt(1)) {
+		t.Error("Remove should find the first element.")
+	}
+	checkSegmentedQueue(t, sq, 3, 4, 5, 6)
+	sq.Remove(adts.IntElt(6))
+	checkSegmentedQueue(t, sq, 3, 4, 5)
+
+	sq.Enqueue(adts.IntElt(7))
+	checkSegmentedQueue(t, sq, 3, 4, 5, 7)
+	if !sq.Contains(adts.IntElt(7)) || sq.Contains(adts.IntElt(6)) {
+		t.Error("Contains should only find queued elements.")
+	}
+
+	sq.Clear()
+	checkSegmentedQueue(t, sq)
+	sq.Enqueue(adts.IntElt(8))
+	checkSegmentedQueue(t, sq, 8)
+}
+
+func TestSegmentedQueueIterator(t *testing.T) {
+	testIterator(t, MakeSegmentedQueue(), []int{0, 1, 2, 3, 4})
+	testIterator(t, NewSegmentedQueue(adts.WithNodeCapacity(2)), []int{0, 1, 2, 3, 4})
+
+	// Removing across segment boundaries, starting part way into the head.
+	sq := makeSegmentedQueueOf(2, 0, 1, 2, 3, 4, 5, 6)
+	sq.Dequeue()
+	it := sq.Iterator()
+	for it.Next() {
+		if it.Value().(adts.IntElt)%2 == 1 && !it.Remove() {
+			t.Errorf("Remove should remove %v.", it.Value())
+		}
+	}
+	checkSegmentedQueue(t, sq, 2, 4, 6)
+}
+
+func TestSegmentedQueueThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeSegmentedQueueThreadSafe(), []int{0, 1, 2, 3, 4})
+
+	// Dequeue only takes the head lock but still counts as a change.
+	sq := MakeSegmentedQueueThreadSafe()
+	sq.Enqueue(adts.IntElt(0))
+	it := sq.Iterator()
+	it.Next()
+	sq.Dequeue()
+	expectConcurrentModification(t, it)
+}
+
+func TestSegmentedQueueRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sq := makeSegmentedQueueOf(3)
+	var model []int
+
+	for op := 0; op < 3000; op++ {
+		elt := r.Intn(20)
+		switch r.Intn(4) {
+		case 0, 1:
+			sq.Enqueue(adts.IntElt(elt))
+			model = append(model, elt)
+		case 2:
+			_, ok := sq.Dequeue()
+			if ok != (len(model) > 0) {
+				t.Fatalf("Dequeue returned the wrong result. Expected: %t", len(model) > 0)
+			}
+			if ok {
+				model = model[1:]
+			}
+		case 3:
+			found := false
+			for i, m := range model {
+				if m == elt {
+					model = append(model[:i:i], model[i+1:]...)
+					found = true
+					break
+				}
+			}
+			if sq.Remove(adts.IntElt(elt)) != found {
+				t.Fatalf("Remove returned the wrong result for %d. Expected: %t", elt, found)
+			}
+		}
+		checkSegmentedQueue(t, sq, model...)
+	}
+}
+
+func TestSegmentedQueueConcurrent(t *testing.T) {
+	sq := NewSegmentedQueue(adts.WithThreadSafety(), adts.WithNodeCapacity(4))
+	const n = 10000
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			sq.Enqueue(adts.IntElt(i))
+		}
+	}()
+
+	for i := 0; i < n; {
+		elt, ok := sq.Dequeue()
+		if !ok {
+			continue
+		}
+		if !elt.Equals(adts.IntElt(i)) {
+			t.Fatalf("Elements came out of order. Expected: %d, Actual: %v", i, elt)
+		}
+		i++
+	}
+	wg.Wait()
+	checkSegmentedQueue(t, sq)
+}
+
+func TestSegmentedQueueAtomic(t *testing.T) {
+	sq := NewSegmentedQueue(adts.WithThreadSafety(), adts.WithNodeCapacity(2))
+	sq.Update(func(tx Queue) error {
+		for i := 0; i < 5; i++ {
+			tx.Enqueue(adts.IntElt(i))
+		}
+		tx.Remove(adts.IntElt(0))
+		return nil
+	})
+	checkSegmentedQueue(t, sq, 1, 2, 3, 4)
+
+	if sq.AddIfAbsent(adts.IntElt(4)) || !sq.AddIfAbsent(adts.IntElt(5)) {
+		t.Error("AddIfAbsent should only add missing elements.")
+	}
+
+	isOne := func(elt adts.ContainerElement) bool { return elt.Equals(adts.IntElt(1)) }
+	if _, ok := sq.DequeueIf(isOne); !ok {
+		t.Error("DequeueIf should dequeue a matching front.")
+	}
+	if _, ok := sq.DequeueIf(isOne); ok {
+		t.Error("DequeueIf shouldn't dequeue a front that doesn't match.")
+	}
+	checkSegmentedQueue(t, sq, 2, 3, 4, 5)
+}
+
+func TestSegmentedQueueClone(t *testing.T) {
+	testQueueClone(t, NewSegmentedQueue(adts.WithThreadSafety(), adts.WithNodeCapacity(2)), func(q Queue) Queue { return q.(*SegmentedQueue).Clone() })
+}
+
+func BenchmarkSegmentedQueueEnqueueDequeue(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []adts.Option
+	}{
+		{"NotThreadSafe", nil},
+		{"ThreadSafe", []adts.Option{adts.WithThreadSafety()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			queue := NewSegmentedQueue(bc.opts...)
+			var elt adts.ContainerElement = adts.IntElt(1)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 64; j++ {
+					queue.Enqueue(elt)
+				}
+				for j := 0; j < 64; j++ {
+					queue.Dequeue()
+				}
+			}
+		})
+	}
+}
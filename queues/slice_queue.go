@@ -9,14 +9,28 @@ type SliceQueue struct {
 	backer *adts.SliceContainer
 }
 
-// MakeSliceQueue creates a non-threadsafe SliceQueue
-func MakeSliceQueue() *SliceQueue {
-	return &SliceQueue{adts.MakeSliceContainer()}
+// NewSliceQueue creates a new SliceQueue configured by the given options.
+func NewSliceQueue(opts ...adts.Option) *SliceQueue {
+	return &SliceQueue{adts.NewSliceContainer(opts...)}
 }
 
-// MakeSliceQueueThreadSafe creates a threadsafe SliceQueue
-func MakeSliceQueueThreadSafe() *SliceQueue {
-	return &SliceQueue{adts.MakeSliceContainerThreadSafe()}
+// MakeSliceQueue creates a new non-threadsafe SliceQueue. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the queue.
+func MakeSliceQueue(eq ...adts.EqualFunc) *SliceQueue {
+	return NewSliceQueue(adts.WithEquality(firstEqual(eq)))
+}
+
+// MakeSliceQueueThreadSafe creates a new threadsafe SliceQueue. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the queue.
+func MakeSliceQueueThreadSafe(eq ...adts.EqualFunc) *SliceQueue {
+	return NewSliceQueue(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the queue, which then records how
+// long it waits on its lock and when it shrinks its backing slice. It must be
+// called before the queue is shared between goroutines.
+func (sq *SliceQueue) SetMetrics(m *adts.Metrics) {
+	sq.backer.Metrics = m
 }
 
 // -------------------------------------------------------
@@ -53,6 +67,12 @@ func (sq *SliceQueue) Remove(item adts.ContainerElement) bool {
 	return sq.backer.Remove(item)
 }
 
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (sq *SliceQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	return sq.backer.RemoveElement(item)
+}
+
 // -------------------------------------------------------
 // Stack Methods
 // -------------------------------------------------------
@@ -64,29 +84,133 @@ func (sq *SliceQueue) Enqueue(item adts.ContainerElement) bool {
 
 // Dequeue removes the element from the head of the queue and returns the element.
 func (sq *SliceQueue) Dequeue() (adts.ContainerElement, bool) {
-	// We want to reuse the Remove method from the SliceContainer class.
-	// The problem is that we need to get the last element in a threadsafe way
-	// (if needed) and then call remove. However, if we lock and then call
-	// Remove, that will also lock, which causes a deadlock. So we need to
-	// do our own locking here and then remove the element and then unlock.
-	if sq.Len() == 0 {
+	// We want to reuse the RemoveAtIndex method from the SliceContainer class,
+	// which doesn't lock. The emptiness check has to happen under the same lock
+	// as the removal, otherwise a concurrent Dequeue could empty the queue in
+	// between the two.
+	if sq.backer.ThreadSafe {
+		sq.backer.Metrics.Lock(sq.backer.Lock)
+		defer sq.backer.Lock.Unlock()
+		return sq.dequeueHelper()
+	}
+
+	return sq.dequeueHelper()
+}
+
+// dequeueHelper removes the element from the head of the queue (in a non-threadsafe way).
+func (sq *SliceQueue) dequeueHelper() (adts.ContainerElement, bool) {
+	if len(sq.backer.Backer) == 0 {
 		return adts.EmptyContainerElement{}, false
 	}
 
-	var firstElt adts.ContainerElement
-	if sq.backer.ThreadSafe {
-		sq.backer.Lock.Lock()
-		defer sq.backer.Lock.Unlock()
-		firstElt = sq.backer.Backer[0]
-		if !sq.backer.RemoveAtIndex(0) {
-			return adts.EmptyContainerElement{}, false
-		}
-	} else {
-		firstElt = sq.backer.Backer[0]
-		if !sq.backer.RemoveAtIndex(0) {
-			return adts.EmptyContainerElement{}, false
-		}
+	firstElt := sq.backer.Backer[0]
+	if !sq.backer.RemoveAtIndex(0) {
+		return adts.EmptyContainerElement{}, false
 	}
 
 	return firstElt, true
 }
+
+// -------------------------------------------------------
+// Capacity Methods
+// -------------------------------------------------------
+
+// Cap returns the capacity of the queue's backing slice.
+func (sq *SliceQueue) Cap() int {
+	return sq.backer.Cap()
+}
+
+// Reserve grows the backing slice, if necessary, so that n more elements can
+// be added without reallocating. It returns false if the queue's
+// CapacityPolicy refuses to grow that far.
+func (sq *SliceQueue) Reserve(n int) bool {
+	return sq.backer.Reserve(n)
+}
+
+// TrimToSize shrinks the backing slice so its capacity equals its length.
+func (sq *SliceQueue) TrimToSize() {
+	sq.backer.TrimToSize()
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the queue from front to back.
+// Its Err returns adts.ErrConcurrentModification if the queue is changed
+// other than through the iterator's Remove.
+func (sq *SliceQueue) Iterator() adts.MutableIterator {
+	return sq.backer.Iterator()
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the queue with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (sq *SliceQueue) Clone() *SliceQueue {
+	return &SliceQueue{sq.backer.Clone()}
+}
+
+// Snapshot returns a copy of the queue's elements from front to back. The
+// elements themselves aren't cloned.
+func (sq *SliceQueue) Snapshot() []adts.ContainerElement {
+	return sq.backer.Snapshot()
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the queue from
+// front to back. The lock (if threadsafe) is only held while the snapshot is
+// copied.
+func (sq *SliceQueue) SnapshotIterator() adts.Iterator {
+	return sq.backer.SnapshotIterator()
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the queue. Changes made before fn returns an error
+// are kept.
+func (sq *SliceQueue) Update(fn func(tx Queue) error) error {
+	return sq.backer.Update(func(tx *adts.SliceContainer) error {
+		return fn(&SliceQueue{tx})
+	})
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the queue. fn must not modify the view.
+func (sq *SliceQueue) View(fn func(tx Queue) error) error {
+	return sq.backer.View(func(tx *adts.SliceContainer) error {
+		return fn(&SliceQueue{tx})
+	})
+}
+
+// Atomically runs fn as a single atomic operation on the queue.
+func (sq *SliceQueue) Atomically(fn func(tx adts.Container) error) error {
+	return sq.Update(func(tx Queue) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent enqueues the given element only if it isn't already in the
+// queue and returns whether it was added.
+func (sq *SliceQueue) AddIfAbsent(item adts.ContainerElement) bool {
+	return sq.backer.AddIfAbsent(item)
+}
+
+// DequeueIf removes the element from the head of the queue only if it
+// matches the given predicate, and returns the element.
+func (sq *SliceQueue) DequeueIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	var dequeued adts.ContainerElement = adts.EmptyContainerElement{}
+	ok := false
+	sq.backer.Update(func(tx *adts.SliceContainer) error {
+		if len(tx.Backer) > 0 && pred(tx.Backer[0]) {
+			dequeued, ok = (&SliceQueue{tx}).dequeueHelper()
+		}
+		return nil
+	})
+
+	return dequeued, ok
+}
@@ -220,3 +220,107 @@ func TestSliceQueueDequeue(t *testing.T) {
 		return
 	}
 }
+
+func TestSliceQueueDequeueThreadSafe(t *testing.T) {
+	max := 1000
+	workers := 8
+
+	queue := MakeSliceQueueThreadSafe()
+	for i := 0; i < max; i++ {
+		queue.Enqueue(adts.IntElt(i))
+	}
+
+	// Every worker dequeues until the queue is empty. If the emptiness check
+	// and the removal aren't atomic then this either panics or loses elements.
+	counts := make(chan int, workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			count := 0
+			for {
+				if _, ok := queue.Dequeue(); !ok {
+					break
+				}
+				count++
+			}
+			counts <- count
+		}()
+	}
+
+	total := 0
+	for w := 0; w < workers; w++ {
+		total += <-counts
+	}
+
+	if total != max {
+		t.Errorf("Concurrent dequeues removed the wrong number of elements. Expected: %d, Actual: %d", max, total)
+	}
+	if !queue.IsEmpty() {
+		t.Errorf("Queue should be empty after all elements are dequeued.")
+	}
+}
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestSliceQueueDequeueIf(t *testing.T) {
+	var a adts.Atomic
+	a = MakeSliceQueueThreadSafe()
+
+	queue := a.(*SliceQueue)
+	for i := 0; i < 10; i++ {
+		queue.Enqueue(adts.IntElt(i))
+	}
+
+	odd := func(elt adts.ContainerElement) bool {
+		return elt.(adts.IntElt)%2 == 1
+	}
+
+	if _, ok := queue.DequeueIf(odd); ok {
+		t.Error("DequeueIf should not dequeue an element that doesn't match.")
+	}
+	queue.Dequeue()
+	if dequeued, ok := queue.DequeueIf(odd); !ok || !dequeued.Equals(adts.IntElt(1)) {
+		t.Errorf("DequeueIf should dequeue a matching element. Expected: %v, Actual: %v", 1, dequeued)
+	}
+	if queue.Len() != 8 {
+		t.Errorf("Expected length: %d, Actual length: %d", 8, queue.Len())
+	}
+	if queue.AddIfAbsent(adts.IntElt(9)) || !queue.AddIfAbsent(adts.IntElt(20)) {
+		t.Error("AddIfAbsent should only add elements that aren't in the queue.")
+	}
+}
+
+func TestSliceQueueCapacity(t *testing.T) {
+	c := NewSliceQueue(adts.WithCapacityPolicy(adts.NeverShrink{}))
+
+	if !c.Reserve(50) || c.Cap() < 50 {
+		t.Errorf("Reserve didn't make room. Expected at least: %d, Actual: %d", 50, c.Cap())
+	}
+	for i := 0; i < 10; i++ {
+		c.Enqueue(adts.IntElt(i))
+	}
+	for i := 0; i < 10; i++ {
+		c.Remove(adts.IntElt(i))
+	}
+	if c.Cap() < 50 {
+		t.Errorf("NeverShrink shouldn't shrink. Expected at least: %d, Actual: %d", 50, c.Cap())
+	}
+
+	c.TrimToSize()
+	if c.Cap() != 0 {
+		t.Errorf("TrimToSize should release the capacity. Expected: %d, Actual: %d", 0, c.Cap())
+	}
+}
+
+func TestSliceQueueClone(t *testing.T) {
+	testQueueClone(t, MakeSliceQueueThreadSafe(), func(q Queue) Queue { return q.(*SliceQueue).Clone() })
+}
+
+func TestSliceQueueIterator(t *testing.T) {
+	testIterator(t, MakeSliceQueue(), []int{0, 1, 2, 3, 4})
+}
+
+func TestSliceQueueThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeSliceQueueThreadSafe(), []int{0, 1, 2, 3, 4})
+}
@@ -0,0 +1,168 @@
+package queueadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// SyncQueue wraps any Queue so that every method call is guarded by a single RWMutex.
+// The wrapped queue should not be used directly once it has been wrapped.
+type SyncQueue struct {
+	inner Queue
+	lock  *sync.RWMutex
+}
+
+// SynchronizedQueue wraps the given queue so that it is safe for concurrent use.
+func SynchronizedQueue(q Queue) *SyncQueue {
+	return &SyncQueue{q, &sync.RWMutex{}}
+}
+
+// WithLock runs fn while holding the write lock, passing it the wrapped
+// queue. This allows compound operations to run without another goroutine
+// seeing the intermediate state. fn must not call back into the SyncQueue,
+// since that would deadlock.
+func (s *SyncQueue) WithLock(fn func(Queue)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(s.inner)
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the queue.
+func (s *SyncQueue) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Len()
+}
+
+// IsEmpty returns if the queue is empty or not.
+func (s *SyncQueue) IsEmpty() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.IsEmpty()
+}
+
+// Clear removes all elements from the queue.
+func (s *SyncQueue) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.inner.Clear()
+}
+
+// Contains returns true if the given item is in the queue.
+func (s *SyncQueue) Contains(item adts.ContainerElement) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the queue.
+func (s *SyncQueue) Add(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Add(item)
+}
+
+// Remove returns true if the given element was removed.
+func (s *SyncQueue) Remove(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (s *SyncQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return adts.RemoveElement(s.inner, item)
+}
+
+// -------------------------------------------------------
+// Queue Methods
+// -------------------------------------------------------
+
+// Enqueue pushes the given element onto the back of the queue.
+func (s *SyncQueue) Enqueue(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Enqueue(item)
+}
+
+// Dequeue removes the element from the front of the queue and returns the element.
+func (s *SyncQueue) Dequeue() (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Dequeue()
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock, passing it the wrapped
+// queue. Changes made before fn returns an error are kept.
+func (s *SyncQueue) Update(fn func(tx Queue) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// View runs fn while holding the read lock, passing it the wrapped queue.
+// fn must not modify the queue.
+func (s *SyncQueue) View(fn func(tx Queue) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return fn(s.inner)
+}
+
+// Atomically runs fn as a single atomic operation on the wrapped queue.
+func (s *SyncQueue) Atomically(fn func(tx adts.Container) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// AddIfAbsent adds the given element only if it isn't already in the
+// queue and returns whether it was added.
+func (s *SyncQueue) AddIfAbsent(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.inner.Contains(item) && s.inner.Add(item)
+}
+
+// DequeueIf removes the element from the front of the queue only if it
+// matches the given predicate, and returns the element.
+func (s *SyncQueue) DequeueIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if inner, ok := s.inner.(interface {
+		DequeueIf(adts.Predicate) (adts.ContainerElement, bool)
+	}); ok {
+		return inner.DequeueIf(pred)
+	}
+
+	// The Queue interface has no way to peek, so dequeue the first element
+	// and, if it doesn't match, rotate the whole queue to put it back in front.
+	first, ok := s.inner.Dequeue()
+	if !ok {
+		return adts.EmptyContainerElement{}, false
+	}
+	if pred(first) {
+		return first, true
+	}
+
+	rest := s.inner.Len()
+	s.inner.Enqueue(first)
+	for i := 0; i < rest; i++ {
+		elt, _ := s.inner.Dequeue()
+		s.inner.Enqueue(elt)
+	}
+
+	return adts.EmptyContainerElement{}, false
+}
@@ -0,0 +1,86 @@
+package queueadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestSynchronizedQueue(t *testing.T) {
+	var q Queue
+	q = SynchronizedQueue(MakeListQueue())
+
+	for i := 0; i < 100; i++ {
+		q.Enqueue(adts.IntElt(i))
+	}
+
+	for i := 0; i < 100; i++ {
+		dequeued, ok := q.Dequeue()
+		if !ok || !dequeued.Equals(adts.IntElt(i)) {
+			t.Errorf("Dequeue didn't return the proper element. Expected: %v, Actual: %v", i, dequeued)
+			return
+		}
+	}
+
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Dequeue should not succeed for an empty queue.")
+	}
+}
+
+func TestSynchronizedQueueConcurrent(t *testing.T) {
+	max := 1000
+	q := SynchronizedQueue(MakeSliceQueue())
+
+	done := make(chan int)
+	for w := 0; w < 4; w++ {
+		go func() {
+			for i := 0; i < max; i++ {
+				q.Enqueue(adts.IntElt(i))
+			}
+			done <- 0
+		}()
+	}
+	for w := 0; w < 4; w++ {
+		<-done
+	}
+
+	total := 0
+	for w := 0; w < 4; w++ {
+		go func() {
+			count := 0
+			for {
+				if _, ok := q.Dequeue(); !ok {
+					break
+				}
+				count++
+			}
+			done <- count
+		}()
+	}
+	for w := 0; w < 4; w++ {
+		total += <-done
+	}
+
+	if total != 4*max {
+		t.Errorf("Expected %d dequeues, Actual: %d", 4*max, total)
+	}
+}
+
+func TestSynchronizedQueueDequeueIf(t *testing.T) {
+	// SyncQueue has to fall back to rotating the queue for this inner queue.
+	q := SynchronizedQueue(SynchronizedQueue(MakeListQueue()))
+	for i := 0; i < 5; i++ {
+		q.Enqueue(adts.IntElt(i))
+	}
+
+	if _, ok := q.DequeueIf(func(elt adts.ContainerElement) bool { return elt.Equals(adts.IntElt(1)) }); ok {
+		t.Error("DequeueIf should not dequeue an element that doesn't match.")
+	}
+	for i := 0; i < 5; i++ {
+		dequeued, _ := q.Dequeue()
+		if !dequeued.Equals(adts.IntElt(i)) {
+			t.Errorf("DequeueIf changed the order of the queue. Expected: %v, Actual: %v", i, dequeued)
+			return
+		}
+	}
+}
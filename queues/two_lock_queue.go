@@ -0,0 +1,457 @@
+package queueadts
+
+import (
+	"sync"
+	"sync/atomic"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// twoLockNode is a node of a TwoLockQueue. next is atomic because the
+// enqueuer sets it under the tail lock while a dequeuer reads it under the
+// head lock.
+type twoLockNode struct {
+	elt  adts.ContainerElement
+	next atomic.Pointer[twoLockNode]
+}
+
+// twoLockChain is the state of a TwoLockQueue, shared by the queue and its
+// unlocked views. head always points at a dummy node whose next node holds
+// the front element, so the head and tail never touch the same field.
+// modCount is atomic because enqueuers and dequeuers change it under
+// different locks.
+type twoLockChain struct {
+	head     *twoLockNode
+	tail     *twoLockNode
+	len      atomic.Int64
+	modCount atomic.Uint64
+}
+
+// TwoLockQueue is the classic two-lock concurrent queue (Michael and Scott).
+// Enqueue only takes the tail lock and Dequeue only takes the head lock, so
+// producers and consumers never contend with each other. Len is maintained
+// atomically and doesn't lock. Contains, Remove and the other operations
+// that look at the whole queue take both locks, head lock first.
+type TwoLockQueue struct {
+	chain      *twoLockChain
+	headLock   *sync.Mutex
+	tailLock   *sync.Mutex
+	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+}
+
+// NewTwoLockQueue creates a new TwoLockQueue configured by the given
+// options. A TwoLockQueue is always threadsafe, and Capacity and
+// ShrinkFactor don't apply, so those options are ignored.
+func NewTwoLockQueue(opts ...adts.Option) *TwoLockQueue {
+	o := adts.MakeOptions(opts...)
+
+	dummy := &twoLockNode{}
+	return &TwoLockQueue{&twoLockChain{head: dummy, tail: dummy}, &sync.Mutex{}, &sync.Mutex{}, true, o.Metrics, o.Equal}
+}
+
+// MakeTwoLockQueue creates a new TwoLockQueue. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the queue.
+func MakeTwoLockQueue(eq ...adts.EqualFunc) *TwoLockQueue {
+	return NewTwoLockQueue(adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the queue, which then records how
+// long it waits on its locks. It must be called before the queue is shared
+// between goroutines.
+func (tq *TwoLockQueue) SetMetrics(m *adts.Metrics) {
+	tq.metrics = m
+}
+
+// lockBoth takes the head lock and then the tail lock.
+func (tq *TwoLockQueue) lockBoth() {
+	tq.metrics.Lock(tq.headLock)
+	tq.metrics.Lock(tq.tailLock)
+}
+
+// unlockBoth releases the locks taken by lockBoth.
+func (tq *TwoLockQueue) unlockBoth() {
+	tq.tailLock.Unlock()
+	tq.headLock.Unlock()
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the queue. It doesn't lock.
+func (tq *TwoLockQueue) Len() int {
+	return int(tq.chain.len.Load())
+}
+
+// IsEmpty returns if the queue is empty or not.
+func (tq *TwoLockQueue) IsEmpty() bool {
+	return tq.Len() == 0
+}
+
+// Clear removes all elements from the queue.
+func (tq *TwoLockQueue) Clear() {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		tq.clearHelper()
+		return
+	}
+
+	tq.clearHelper()
+}
+
+// clearHelper unlinks every node after the dummy (in a non-threadsafe way).
+func (tq *TwoLockQueue) clearHelper() {
+	tq.chain.head.next.Store(nil)
+	tq.chain.tail = tq.chain.head
+	tq.chain.len.Store(0)
+	tq.chain.modCount.Add(1)
+}
+
+// Contains returns true if the given item is in the queue.
+func (tq *TwoLockQueue) Contains(item adts.ContainerElement) bool {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		return tq.containsHelper(item)
+	}
+
+	return tq.containsHelper(item)
+}
+
+// containsHelper checks the nodes for the given element (in a non-threadsafe way).
+func (tq *TwoLockQueue) containsHelper(item adts.ContainerElement) bool {
+	for tmp := tq.chain.head.next.Load(); tmp != nil; tmp = tmp.next.Load() {
+		if tq.equal.Equal(tmp.elt, item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add returns true if the given element was added to the back of the queue.
+func (tq *TwoLockQueue) Add(item adts.ContainerElement) bool {
+	if tq.threadSafe {
+		tq.metrics.Lock(tq.tailLock)
+		defer tq.tailLock.Unlock()
+		return tq.addHelper(item)
+	}
+
+	return tq.addHelper(item)
+}
+
+// addHelper links a new node after the tail. Only the tail side is touched,
+// and the node is filled in before it's published.
+func (tq *TwoLockQueue) addHelper(item adts.ContainerElement) bool {
+	n := &twoLockNode{elt: item}
+
+	// Counting first means Len never goes negative when a dequeuer takes
+	// the element before this returns.
+	tq.chain.len.Add(1)
+	tq.chain.modCount.Add(1)
+	tq.chain.tail.next.Store(n)
+	tq.chain.tail = n
+	return true
+}
+
+// Remove returns true if the given element was removed.
+func (tq *TwoLockQueue) Remove(item adts.ContainerElement) bool {
+	_, ok := tq.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (tq *TwoLockQueue) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		return tq.removeHelper(item)
+	}
+
+	return tq.removeHelper(item)
+}
+
+// removeHelper unlinks the first node holding the element (in a
+// non-threadsafe way).
+func (tq *TwoLockQueue) removeHelper(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	prev := tq.chain.head
+	for tmp := prev.next.Load(); tmp != nil; prev, tmp = tmp, tmp.next.Load() {
+		if tq.equal.Equal(tmp.elt, item) {
+			tq.unlinkHelper(prev, tmp)
+			return tmp.elt, true
+		}
+	}
+
+	return adts.EmptyContainerElement{}, false
+}
+
+// unlinkHelper unlinks the node after prev. Both locks must be held.
+func (tq *TwoLockQueue) unlinkHelper(prev, n *twoLockNode) {
+	prev.next.Store(n.next.Load())
+	if n == tq.chain.tail {
+		tq.chain.tail = prev
+	}
+	tq.chain.len.Add(-1)
+	tq.chain.modCount.Add(1)
+}
+
+// -------------------------------------------------------
+// Queue Methods
+// -------------------------------------------------------
+
+// Enqueue pushes the given element onto the back of the queue.
+func (tq *TwoLockQueue) Enqueue(item adts.ContainerElement) bool {
+	return tq.Add(item)
+}
+
+// Dequeue removes the element from the front of the queue and returns the element.
+func (tq *TwoLockQueue) Dequeue() (adts.ContainerElement, bool) {
+	if tq.threadSafe {
+		tq.metrics.Lock(tq.headLock)
+		defer tq.headLock.Unlock()
+		return tq.dequeueHelper()
+	}
+
+	return tq.dequeueHelper()
+}
+
+// dequeueHelper makes the first node the new dummy and returns its element.
+// Only the head side is touched.
+func (tq *TwoLockQueue) dequeueHelper() (adts.ContainerElement, bool) {
+	first := tq.chain.head.next.Load()
+	if first == nil {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	elt := first.elt
+	first.elt = nil
+	tq.chain.head = first
+	tq.chain.len.Add(-1)
+	tq.chain.modCount.Add(1)
+	return elt, true
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the queue from front to back. Its Err
+// returns adts.ErrConcurrentModification if the queue is changed other than
+// through the iterator's Remove, including by an Enqueue or Dequeue. Next and
+// Remove take both locks.
+func (tq *TwoLockQueue) Iterator() adts.MutableIterator {
+	return &twoLockQueueIterator{tq: tq, expected: tq.chain.modCount.Load()}
+}
+
+// twoLockQueueIterator is the fail-fast iterator of a TwoLockQueue. It keeps
+// the node before the current one so Remove can unlink the current node.
+type twoLockQueueIterator struct {
+	tq        *TwoLockQueue
+	prev      *twoLockNode
+	cur       *twoLockNode
+	val       adts.ContainerElement
+	started   bool
+	removed   bool
+	expected  uint64
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the queue has been changed.
+func (it *twoLockQueueIterator) Next() bool {
+	if it.tq.threadSafe {
+		it.tq.lockBoth()
+		defer it.tq.unlockBoth()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.tq.chain.modCount.Load()); it.err != nil {
+		return false
+	}
+
+	switch {
+	case !it.started:
+		it.prev, it.started = it.tq.chain.head, true
+	case it.removed:
+		// cur was unlinked, so the next node hangs off prev.
+		it.removed = false
+	case it.cur != nil:
+		it.prev = it.cur
+	}
+
+	if it.cur = it.prev.next.Load(); it.cur == nil {
+		it.val = nil
+		return false
+	}
+
+	it.val = it.cur.elt
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *twoLockQueueIterator) Value() adts.ContainerElement {
+	if it.val == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.val
+}
+
+// Err returns adts.ErrConcurrentModification if the queue was changed during
+// iteration.
+func (it *twoLockQueueIterator) Err() error {
+	return it.err
+}
+
+// Remove unlinks the current element from the queue.
+func (it *twoLockQueueIterator) Remove() bool {
+	if it.tq.threadSafe {
+		it.tq.lockBoth()
+		defer it.tq.unlockBoth()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.tq.chain.modCount.Load()); it.err != nil {
+		return false
+	}
+
+	it.tq.unlinkHelper(it.prev, it.cur)
+	it.expected = it.tq.chain.modCount.Load()
+	it.removable = false
+	it.removed = true
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the queue with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (tq *TwoLockQueue) Clone() *TwoLockQueue {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		return tq.cloneHelper()
+	}
+
+	return tq.cloneHelper()
+}
+
+// cloneHelper copies the queue element by element without locking.
+func (tq *TwoLockQueue) cloneHelper() *TwoLockQueue {
+	clone := NewTwoLockQueue(adts.WithMetrics(tq.metrics), adts.WithEquality(tq.equal))
+	for tmp := tq.chain.head.next.Load(); tmp != nil; tmp = tmp.next.Load() {
+		clone.addHelper(adts.CloneElement(tmp.elt))
+	}
+
+	return clone
+}
+
+// Snapshot returns a copy of the queue's elements from front to back. The
+// elements themselves aren't cloned.
+func (tq *TwoLockQueue) Snapshot() []adts.ContainerElement {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		return tq.snapshotHelper()
+	}
+
+	return tq.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (tq *TwoLockQueue) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, tq.chain.len.Load())
+	for tmp := tq.chain.head.next.Load(); tmp != nil; tmp = tmp.next.Load() {
+		elts = append(elts, tmp.elt)
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the queue from front to back.
+// The locks are only held while the snapshot is copied.
+func (tq *TwoLockQueue) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(tq.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the queue that shares its nodes.
+func (tq *TwoLockQueue) unlocked() *TwoLockQueue {
+	return &TwoLockQueue{tq.chain, tq.headLock, tq.tailLock, false, tq.metrics, tq.equal}
+}
+
+// Update runs fn while holding both locks, passing it a non-threadsafe view
+// of the queue. Changes made before fn returns an error are kept.
+func (tq *TwoLockQueue) Update(fn func(tx Queue) error) error {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		return fn(tq.unlocked())
+	}
+
+	return fn(tq.unlocked())
+}
+
+// View runs fn while holding both locks, passing it a non-threadsafe view of
+// the queue. fn must not modify the view.
+func (tq *TwoLockQueue) View(fn func(tx Queue) error) error {
+	return tq.Update(fn)
+}
+
+// Atomically runs fn as a single atomic operation on the queue.
+func (tq *TwoLockQueue) Atomically(fn func(tx adts.Container) error) error {
+	return tq.Update(func(tx Queue) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent enqueues the given element only if it isn't already in the
+// queue and returns whether it was added.
+func (tq *TwoLockQueue) AddIfAbsent(item adts.ContainerElement) bool {
+	if tq.threadSafe {
+		tq.lockBoth()
+		defer tq.unlockBoth()
+		return !tq.containsHelper(item) && tq.addHelper(item)
+	}
+
+	return !tq.containsHelper(item) && tq.addHelper(item)
+}
+
+// DequeueIf removes the element from the front of the queue only if it
+// matches the given predicate, and returns the element. It only takes the
+// head lock.
+func (tq *TwoLockQueue) DequeueIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	if tq.threadSafe {
+		tq.metrics.Lock(tq.headLock)
+		defer tq.headLock.Unlock()
+		return tq.dequeueIfHelper(pred)
+	}
+
+	return tq.dequeueIfHelper(pred)
+}
+
+// dequeueIfHelper peeks at the front of the queue and only dequeues it if it matches.
+func (tq *TwoLockQueue) dequeueIfHelper(pred adts.Predicate) (adts.ContainerElement, bool) {
+	first := tq.chain.head.next.Load()
+	if first == nil || !pred(first.elt) {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return tq.dequeueHelper()
+}
@@ -0,0 +1,205 @@
+package queueadts
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// checkTwoLockQueue checks the queue holds exactly the given ints, front
+// first, and that the tail is the last node.
+func checkTwoLockQueue(t *testing.T, tq *TwoLockQueue, expected ...int) {
+	t.Helper()
+
+	actual := tq.Snapshot()
+	if len(actual) != len(expected) || tq.Len() != len(expected) {
+		t.Fatalf("Wrong length. Expected: %d, Actual: %d (Len %d)", len(expected), len(actual), tq.Len())
+	}
+	for i, elt := range actual {
+		if !elt.Equals(adts.IntElt(expected[i])) {
+			t.Fatalf("Wrong element at %d. Expected: %v, Actual: %v", i, expected, actual)
+		}
+	}
+
+	if tq.chain.tail.next.Load() != nil || tq.chain.head.elt != nil {
+		t.Fatal("The tail should be the last node and the dummy should be empty.")
+	}
+}
+
+// makeTwoLockQueueOf returns a TwoLockQueue holding the given ints.
+func makeTwoLockQueueOf(elts ...int) *TwoLockQueue {
+	tq := MakeTwoLockQueue()
+	for _, elt := range elts {
+		tq.Enqueue(adts.IntElt(elt))
+	}
+
+	return tq
+}
+
+func TestMakeTwoLockQueue(t *testing.T) {
+	tq := MakeTwoLockQueue()
+	if !tq.threadSafe || !tq.IsEmpty() || tq.chain.head != tq.chain.tail {
+		t.Errorf("Unexpected new queue. Actual: %+v", tq)
+	}
+
+	var q Queue = NewTwoLockQueue(adts.WithMetrics(adts.MakeMetrics()))
+	if q.Len() != 0 {
+		t.Error("Length of empty queue should be 0.")
+	}
+}
+
+func TestTwoLockQueueEnqueueDequeue(t *testing.T) {
+	tq := makeTwoLockQueueOf(0, 1, 2)
+	checkTwoLockQueue(t, tq, 0, 1, 2)
+
+	for i := 0; i < 3; i++ {
+		if elt, ok := tq.Dequeue(); !ok || !elt.Equals(adts.IntElt(i)) {
+			t.Fatalf("Dequeue returned the wrong element. Expected: %d, Actual: %v", i, elt)
+		}
+	}
+	if _, ok := tq.Dequeue(); ok {
+		t.Error("Dequeue on an empty queue should fail.")
+	}
+	checkTwoLockQueue(t, tq)
+
+	tq.Enqueue(adts.IntElt(3))
+	checkTwoLockQueue(t, tq, 3)
+}
+
+func TestTwoLockQueueRemove(t *testing.T) {
+	tq := makeTwoLockQueueOf(0, 1, 2, 3)
+
+	if !tq.Remove(adts.IntElt(1)) || tq.Remove(adts.IntElt(1)) {
+		t.Error("Remove should remove an element once.")
+	}
+	checkTwoLockQueue(t, tq, 0, 2, 3)
+
+	// Removing the last node moves the tail back.
+	tq.Remove(adts.IntElt(3))
+	checkTwoLockQueue(t, tq, 0, 2)
+	tq.Enqueue(adts.IntElt(4))
+	checkTwoLockQueue(t, tq, 0, 2, 4)
+
+	if !tq.Contains(adts.IntElt(4)) || tq.Contains(adts.IntElt(3)) {
+		t.Error("Contains should only find queued elements.")
+	}
+
+	tq.Clear()
+	checkTwoLockQueue(t, tq)
+	tq.Enqueue(adts.IntElt(5))
+	checkTwoLockQueue(t, tq, 5)
+}
+
+func TestTwoLockQueueIterator(t *testing.T) {
+	testIterator(t, MakeTwoLockQueue(), []int{0, 1, 2, 3, 4})
+
+	// Removing the last node through the iterator moves the tail back.
+	tq := makeTwoLockQueueOf(0, 1, 2)
+	it := tq.Iterator()
+	for it.Next() {
+		if it.Value().Equals(adts.IntElt(2)) && !it.Remove() {
+			t.Error("Remove should remove the last element.")
+		}
+	}
+	tq.Enqueue(adts.IntElt(3))
+	checkTwoLockQueue(t, tq, 0, 1, 3)
+
+	// Dequeue only takes the head lock but still counts as a change.
+	it = tq.Iterator()
+	it.Next()
+	tq.Dequeue()
+	expectConcurrentModification(t, it)
+}
+
+func TestTwoLockQueueConcurrent(t *testing.T) {
+	tq := MakeTwoLockQueue()
+	const producers, n = 4, 2000
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				tq.Enqueue(adts.IntElt(p*n + i))
+			}
+		}(p)
+	}
+
+	// Each producer's elements must come out in the order it enqueued them.
+	next := make([]int, producers)
+	for got := 0; got < producers*n; {
+		elt, ok := tq.Dequeue()
+		if !ok {
+			continue
+		}
+		v := int(elt.(adts.IntElt))
+		if p := v / n; v%n != next[p] {
+			t.Fatalf("Producer %d's elements came out of order. Expected: %d, Actual: %d", p, next[p], v%n)
+		} else {
+			next[p]++
+		}
+		got++
+	}
+	wg.Wait()
+	checkTwoLockQueue(t, tq)
+}
+
+func TestTwoLockQueueAtomic(t *testing.T) {
+	tq := MakeTwoLockQueue()
+	tq.Update(func(tx Queue) error {
+		for i := 0; i < 5; i++ {
+			tx.Enqueue(adts.IntElt(i))
+		}
+		tx.Remove(adts.IntElt(0))
+		return nil
+	})
+	checkTwoLockQueue(t, tq, 1, 2, 3, 4)
+
+	if tq.AddIfAbsent(adts.IntElt(4)) || !tq.AddIfAbsent(adts.IntElt(5)) {
+		t.Error("AddIfAbsent should only add missing elements.")
+	}
+
+	isOne := func(elt adts.ContainerElement) bool { return elt.Equals(adts.IntElt(1)) }
+	if _, ok := tq.DequeueIf(isOne); !ok {
+		t.Error("DequeueIf should dequeue a matching front.")
+	}
+	if _, ok := tq.DequeueIf(isOne); ok {
+		t.Error("DequeueIf shouldn't dequeue a front that doesn't match.")
+	}
+	checkTwoLockQueue(t, tq, 2, 3, 4, 5)
+}
+
+func TestTwoLockQueueClone(t *testing.T) {
+	testQueueClone(t, MakeTwoLockQueue(), func(q Queue) Queue { return q.(*TwoLockQueue).Clone() })
+}
+
+func BenchmarkTwoLockQueueProducerConsumer(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		queue Queue
+	}{
+		{"ListQueue", MakeListQueueThreadSafe()},
+		{"TwoLockQueue", MakeTwoLockQueue()},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var elt adts.ContainerElement = adts.IntElt(1)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < b.N; i++ {
+					bc.queue.Enqueue(elt)
+				}
+			}()
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; {
+				if _, ok := bc.queue.Dequeue(); ok {
+					i++
+				}
+			}
+			<-done
+		})
+	}
+}
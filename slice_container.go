@@ -7,26 +7,42 @@ import (
 // SliceContainer is a simple type that implements the Container interface.
 type SliceContainer struct {
 	Backer       []ContainerElement
-	Lock         *sync.Mutex
+	Lock         *sync.RWMutex
 	ThreadSafe   bool
 	ShrinkFactor float32
+	Metrics      *Metrics
+	Equal        EqualFunc
+	Policy       CapacityPolicy
+
+	// modCount counts structural changes so iterators can detect them.
+	modCount uint64
+}
+
+// NewSliceContainer creates a new SliceContainer configured by the given
+// options.
+func NewSliceContainer(opts ...Option) *SliceContainer {
+	o := MakeOptions(opts...)
+	return &SliceContainer{make([]ContainerElement, 0, o.Capacity), &sync.RWMutex{}, o.ThreadSafe, o.ShrinkFactor, o.Metrics, o.Equal, o.CapacityPolicy, 0}
 }
 
-// MakeSliceContainer creates a new non-threadsafe SliceContainer.
-func MakeSliceContainer() *SliceContainer {
-	return &SliceContainer{[]ContainerElement{}, &sync.Mutex{}, false, 0.25}
+// MakeSliceContainer creates a new non-threadsafe SliceContainer. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the container.
+func MakeSliceContainer(eq ...EqualFunc) *SliceContainer {
+	return NewSliceContainer(WithEquality(firstEqual(eq)))
 }
 
-// MakeSliceContainerThreadSafe creates a new threadsafe SliceContainer.
-func MakeSliceContainerThreadSafe() *SliceContainer {
-	return &SliceContainer{[]ContainerElement{}, &sync.Mutex{}, true, 0.25}
+// MakeSliceContainerThreadSafe creates a new threadsafe SliceContainer. An
+// optional EqualFunc replaces ContainerElement.Equals when searching the
+// container.
+func MakeSliceContainerThreadSafe(eq ...EqualFunc) *SliceContainer {
+	return NewSliceContainer(WithThreadSafety(), WithEquality(firstEqual(eq)))
 }
 
 // Len returns the number of elements in the container.
 func (sc *SliceContainer) Len() int {
 	if sc.ThreadSafe {
-		sc.Lock.Lock()
-		defer sc.Lock.Unlock()
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
 		return len(sc.Backer)
 	}
 	return len(sc.Backer)
@@ -40,20 +56,38 @@ func (sc *SliceContainer) IsEmpty() bool {
 // Clear removes all elements from the container.
 func (sc *SliceContainer) Clear() {
 	if sc.ThreadSafe {
-		sc.Lock.Lock()
+		sc.Metrics.Lock(sc.Lock)
 		defer sc.Lock.Unlock()
+		sc.clearHelper()
+		return
+	}
+
+	sc.clearHelper()
+}
+
+// clearHelper empties the container. Without a policy the backing slice is
+// dropped, otherwise the policy decides how much capacity to keep.
+func (sc *SliceContainer) clearHelper() {
+	sc.modCount++
+	if sc.Policy == nil {
 		sc.Backer = []ContainerElement{}
 		return
 	}
 
-	sc.Backer = []ContainerElement{}
+	// Zero the old elements so the backing array doesn't keep them alive.
+	clear(sc.Backer)
+	sc.Backer = sc.Backer[:0]
+	if newCap := sc.Policy.Shrink(0, cap(sc.Backer)); newCap < cap(sc.Backer) {
+		sc.resize(newCap)
+		sc.Metrics.ObserveShrink()
+	}
 }
 
 // Contains returns true if the given item is in the container.
 func (sc *SliceContainer) Contains(item ContainerElement) bool {
 	if sc.ThreadSafe {
-		sc.Lock.Lock()
-		defer sc.Lock.Unlock()
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
 		return sc.containsHelper(item)
 	}
 
@@ -63,7 +97,7 @@ func (sc *SliceContainer) Contains(item ContainerElement) bool {
 // containsHelper returns whether the given element is in the container.
 func (sc *SliceContainer) containsHelper(item ContainerElement) bool {
 	for _, i := range sc.Backer {
-		if i.Equals(item) {
+		if sc.Equal.Equal(i, item) {
 			return true
 		}
 	}
@@ -71,38 +105,72 @@ func (sc *SliceContainer) containsHelper(item ContainerElement) bool {
 	return false
 }
 
-// Add returns true if the given element was appended to the end of the container.
+// Add returns true if the given element was appended to the end of the
+// container. It only returns false if the container's CapacityPolicy refuses
+// to grow.
 func (sc *SliceContainer) Add(item ContainerElement) bool {
 	if sc.ThreadSafe {
-		sc.Lock.Lock()
+		sc.Metrics.Lock(sc.Lock)
 		defer sc.Lock.Unlock()
-		sc.Backer = append(sc.Backer, item)
-	} else {
-		sc.Backer = append(sc.Backer, item)
+		return sc.addHelper(item)
+	}
+
+	return sc.addHelper(item)
+}
+
+// addHelper appends the given element, asking the policy (if any) how much
+// to grow.
+func (sc *SliceContainer) addHelper(item ContainerElement) bool {
+	if sc.Policy != nil && !sc.growHelper(len(sc.Backer)+1) {
+		return false
 	}
 
+	sc.Backer = append(sc.Backer, item)
+	sc.modCount++
 	return true
 }
 
 // Remove returns true if the given element was removed.
 func (sc *SliceContainer) Remove(item ContainerElement) bool {
+	_, ok := sc.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (sc *SliceContainer) RemoveElement(item ContainerElement) (ContainerElement, bool) {
 	if sc.ThreadSafe {
-		sc.Lock.Lock()
+		sc.Metrics.Lock(sc.Lock)
 		defer sc.Lock.Unlock()
-		idx := sc.findHelper(item)
-		if idx < 0 {
-			return false
-		}
-		sc.RemoveAtIndex(idx)
-	} else {
-		idx := sc.findHelper(item)
-		if idx < 0 {
-			return false
-		}
-		sc.RemoveAtIndex(idx)
+		return sc.removeElementHelper(item)
 	}
 
-	return true
+	return sc.removeElementHelper(item)
+}
+
+// removeElementHelper finds the element and removes it by index (in a
+// non-threadsafe way).
+func (sc *SliceContainer) removeElementHelper(item ContainerElement) (ContainerElement, bool) {
+	idx := sc.findHelper(item)
+	if idx < 0 {
+		return EmptyContainerElement{}, false
+	}
+
+	elt := sc.Backer[idx]
+	sc.RemoveAtIndex(idx)
+	return elt, true
+}
+
+// IndexOf returns the index of the first element equal to the given item, or
+// -1 if there isn't one.
+func (sc *SliceContainer) IndexOf(item ContainerElement) int {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
+		return sc.findHelper(item)
+	}
+
+	return sc.findHelper(item)
 }
 
 // findHelper searches the list for the given element and returns the index
@@ -110,7 +178,7 @@ func (sc *SliceContainer) Remove(item ContainerElement) bool {
 // is returned.
 func (sc *SliceContainer) findHelper(item ContainerElement) int {
 	for idx, val := range sc.Backer {
-		if item.Equals(val) {
+		if sc.Equal.Equal(item, val) {
 			return idx
 		}
 	}
@@ -118,26 +186,334 @@ func (sc *SliceContainer) findHelper(item ContainerElement) int {
 	return -1
 }
 
-// RemoveAtIndex removes the element at the given idx.
+// RemoveAtIndex removes the element at the given idx. It does not lock, so
+// threadsafe callers must already be holding the write lock.
 func (sc *SliceContainer) RemoveAtIndex(idx int) bool {
-	if idx < 0 || idx > len(sc.Backer) {
+	if idx < 0 || idx >= len(sc.Backer) {
 		return false
 	}
 
 	sc.Backer = append(sc.Backer[:idx], sc.Backer[idx+1:]...)
+	sc.modCount++
 
 	// We should check to see if we need to resize the slice. We don't
 	// want it to be the case that we added a ton of items then removed
 	// a bunch and now we are still holding onto the large backing array
 	// for the slice.
+	if sc.Policy != nil {
+		newCap := sc.Policy.Shrink(len(sc.Backer), cap(sc.Backer))
+		if newCap >= len(sc.Backer) && newCap < cap(sc.Backer) {
+			sc.resize(newCap)
+			sc.Metrics.ObserveShrink()
+		}
+		return true
+	}
+
 	emptyFactor := float32(len(sc.Backer)) / float32(cap(sc.Backer))
 	if emptyFactor <= sc.ShrinkFactor {
 		// Shrink the slice's capacity by 1/2
-		newCap := cap(sc.Backer) / 2
-		newBacker := make([]ContainerElement, len(sc.Backer), newCap)
-		copy(newBacker, sc.Backer)
-		sc.Backer = newBacker
+		sc.resize(cap(sc.Backer) / 2)
+		sc.Metrics.ObserveShrink()
+	}
+
+	return true
+}
+
+// -------------------------------------------------------
+// Capacity Methods
+// -------------------------------------------------------
+
+// Cap returns the capacity of the container's backing slice.
+func (sc *SliceContainer) Cap() int {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
+		return cap(sc.Backer)
+	}
+
+	return cap(sc.Backer)
+}
+
+// Reserve grows the backing slice, if necessary, so that n more elements can
+// be added without reallocating. The CapacityPolicy (if any) picks the new
+// capacity and may refuse, in which case Reserve returns false. A later
+// removal may shrink the slice again, depending on the policy.
+func (sc *SliceContainer) Reserve(n int) bool {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock)
+		defer sc.Lock.Unlock()
+		return sc.growHelper(len(sc.Backer) + n)
+	}
+
+	return sc.growHelper(len(sc.Backer) + n)
+}
+
+// TrimToSize shrinks the backing slice so its capacity equals its length.
+func (sc *SliceContainer) TrimToSize() {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock)
+		defer sc.Lock.Unlock()
+		sc.trimHelper()
+		return
 	}
 
+	sc.trimHelper()
+}
+
+// trimHelper reallocates the backing slice to exactly fit its elements.
+func (sc *SliceContainer) trimHelper() {
+	if len(sc.Backer) < cap(sc.Backer) {
+		sc.resize(len(sc.Backer))
+		sc.Metrics.ObserveShrink()
+	}
+}
+
+// growHelper makes sure the backing slice can hold need elements. It returns
+// false if the policy refuses to grow that far.
+func (sc *SliceContainer) growHelper(need int) bool {
+	if need <= cap(sc.Backer) {
+		return true
+	}
+
+	newCap := need
+	if sc.Policy != nil {
+		newCap = sc.Policy.Grow(cap(sc.Backer), need)
+		if newCap < need {
+			return false
+		}
+	}
+
+	sc.resize(newCap)
 	return true
 }
+
+// resize copies the elements into a new backing slice of the given capacity.
+func (sc *SliceContainer) resize(newCap int) {
+	newBacker := make([]ContainerElement, len(sc.Backer), newCap)
+	copy(newBacker, sc.Backer)
+	sc.Backer = newBacker
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the container from the first
+// element to the last. If threadsafe, each step holds the read lock and
+// Remove holds the write lock.
+func (sc *SliceContainer) Iterator() MutableIterator {
+	return sc.makeIterator(false)
+}
+
+// ReverseIterator returns a fail-fast iterator over the container from the
+// last element to the first.
+func (sc *SliceContainer) ReverseIterator() MutableIterator {
+	return sc.makeIterator(true)
+}
+
+// makeIterator creates an iterator that starts just outside the end it walks
+// from.
+func (sc *SliceContainer) makeIterator(reverse bool) *sliceContainerIterator {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
+	}
+
+	it := &sliceContainerIterator{sc: sc, idx: -1, expected: sc.modCount}
+	if reverse {
+		it.idx, it.step = len(sc.Backer), -1
+	} else {
+		it.step = 1
+	}
+
+	return it
+}
+
+// sliceContainerIterator is the fail-fast iterator of a SliceContainer.
+type sliceContainerIterator struct {
+	sc        *SliceContainer
+	idx       int
+	step      int
+	expected  uint64
+	cur       ContainerElement
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the container has been changed.
+func (it *sliceContainerIterator) Next() bool {
+	if it.sc.ThreadSafe {
+		it.sc.Metrics.Lock(it.sc.Lock.RLocker())
+		defer it.sc.Lock.RUnlock()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = CheckModCount(it.expected, it.sc.modCount); it.err != nil {
+		return false
+	}
+
+	it.idx += it.step
+	if it.idx < 0 || it.idx >= len(it.sc.Backer) {
+		it.idx -= it.step
+		it.cur = nil
+		return false
+	}
+
+	it.cur = it.sc.Backer[it.idx]
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *sliceContainerIterator) Value() ContainerElement {
+	if it.cur == nil {
+		return EmptyContainerElement{}
+	}
+
+	return it.cur
+}
+
+// Err returns ErrConcurrentModification if the container was changed during
+// iteration.
+func (it *sliceContainerIterator) Err() error {
+	return it.err
+}
+
+// Remove removes the current element from the container.
+func (it *sliceContainerIterator) Remove() bool {
+	if it.sc.ThreadSafe {
+		it.sc.Metrics.Lock(it.sc.Lock)
+		defer it.sc.Lock.Unlock()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = CheckModCount(it.expected, it.sc.modCount); it.err != nil {
+		return false
+	}
+
+	it.sc.RemoveAtIndex(it.idx)
+	it.expected = it.sc.modCount
+	it.removable = false
+
+	// Going forward, the next element has moved into the removed slot.
+	if it.step > 0 {
+		it.idx--
+	}
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the SliceContainer with the same settings and its
+// own backing slice. Elements that implement Cloner are cloned.
+func (sc *SliceContainer) Clone() *SliceContainer {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
+		return sc.cloneHelper()
+	}
+
+	return sc.cloneHelper()
+}
+
+// cloneHelper copies the container without locking.
+func (sc *SliceContainer) cloneHelper() *SliceContainer {
+	return &SliceContainer{CloneElements(sc.Backer), &sync.RWMutex{}, sc.ThreadSafe, sc.ShrinkFactor, sc.Metrics, sc.Equal, sc.Policy, 0}
+}
+
+// Snapshot returns a copy of the container's elements in order. The elements
+// themselves aren't cloned.
+func (sc *SliceContainer) Snapshot() []ContainerElement {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
+		return append([]ContainerElement{}, sc.Backer...)
+	}
+
+	return append([]ContainerElement{}, sc.Backer...)
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the container. The
+// lock (if threadsafe) is only held while the snapshot is copied.
+func (sc *SliceContainer) SnapshotIterator() Iterator {
+	return MakeSliceIterator(sc.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the container that shares its
+// backing slice. Any changes made through the view must be copied back with
+// commit.
+func (sc *SliceContainer) unlocked() *SliceContainer {
+	return &SliceContainer{sc.Backer, sc.Lock, false, sc.ShrinkFactor, sc.Metrics, sc.Equal, sc.Policy, sc.modCount}
+}
+
+// commit copies the state of the given view back into the container.
+func (sc *SliceContainer) commit(view *SliceContainer) {
+	sc.Backer = view.Backer
+	sc.modCount = view.modCount
+}
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the container. Changes made before fn returns an
+// error are kept.
+func (sc *SliceContainer) Update(fn func(tx *SliceContainer) error) error {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock)
+		defer sc.Lock.Unlock()
+		return sc.updateHelper(fn)
+	}
+
+	return sc.updateHelper(fn)
+}
+
+// updateHelper runs fn against a view of the container and then copies the
+// view's state back into the container.
+func (sc *SliceContainer) updateHelper(fn func(tx *SliceContainer) error) error {
+	view := sc.unlocked()
+	defer sc.commit(view)
+	return fn(view)
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the container. fn must not modify the view.
+func (sc *SliceContainer) View(fn func(tx *SliceContainer) error) error {
+	if sc.ThreadSafe {
+		sc.Metrics.Lock(sc.Lock.RLocker())
+		defer sc.Lock.RUnlock()
+		return fn(sc.unlocked())
+	}
+
+	return fn(sc.unlocked())
+}
+
+// Atomically runs fn as a single atomic operation on the container.
+func (sc *SliceContainer) Atomically(fn func(tx Container) error) error {
+	return sc.Update(func(tx *SliceContainer) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent appends the given element only if it isn't already in the
+// container and returns whether it was added.
+func (sc *SliceContainer) AddIfAbsent(item ContainerElement) bool {
+	added := false
+	sc.Update(func(tx *SliceContainer) error {
+		if !tx.containsHelper(item) {
+			added = tx.Add(item)
+		}
+		return nil
+	})
+
+	return added
+}
@@ -166,3 +166,107 @@ func TestSliceContainerRemove(t *testing.T) {
 		}
 	}
 }
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestSliceContainerAddIfAbsent(t *testing.T) {
+	var c Atomic
+	c = MakeSliceContainerThreadSafe()
+
+	done := make(chan bool)
+	for w := 0; w < 8; w++ {
+		go func() {
+			for i := 0; i < 100; i++ {
+				c.(*SliceContainer).AddIfAbsent(IntElt(i))
+			}
+			done <- true
+		}()
+	}
+	for w := 0; w < 8; w++ {
+		<-done
+	}
+
+	if c.Len() != 100 {
+		t.Errorf("Each value should only be added once. Expected length: %d, Actual length: %d", 100, c.Len())
+	}
+}
+
+func TestSliceContainerUpdate(t *testing.T) {
+	container := MakeSliceContainerThreadSafe()
+
+	err := container.Update(func(tx *SliceContainer) error {
+		for i := 0; i < 10; i++ {
+			tx.Add(IntElt(i))
+		}
+		tx.Remove(IntElt(0))
+		return fmt.Errorf("stop")
+	})
+
+	if err == nil || err.Error() != "stop" {
+		t.Errorf("Update should return the error from fn. Actual: %v", err)
+	}
+	if container.Len() != 9 {
+		t.Errorf("Changes made in Update should be kept. Expected length: %d, Actual length: %d", 9, container.Len())
+	}
+
+	container.View(func(tx *SliceContainer) error {
+		if tx.Contains(IntElt(0)) || !tx.Contains(IntElt(9)) {
+			t.Error("View should see the changes made in Update.")
+		}
+		return nil
+	})
+}
+
+func TestSliceContainerIterator(t *testing.T) {
+	for _, sc := range []*SliceContainer{MakeSliceContainer(), MakeSliceContainerThreadSafe()} {
+		for i := 0; i < 5; i++ {
+			sc.Add(IntElt(i))
+		}
+
+		idx := 0
+		for it := sc.Iterator(); it.Next(); idx++ {
+			if !it.Value().Equals(IntElt(idx)) {
+				t.Errorf("Wrong element. Expected: %d, Actual: %v", idx, it.Value())
+			}
+			if idx%2 == 0 && !it.Remove() {
+				t.Error("Remove should succeed during iteration.")
+			}
+		}
+		if idx != 5 || sc.Len() != 2 {
+			t.Errorf("Remove should remove the even elements. Expected: %d, Actual: %d", 2, sc.Len())
+		}
+
+		idx = 0
+		expected := []int{3, 1}
+		for it := sc.ReverseIterator(); it.Next(); idx++ {
+			if !it.Value().Equals(IntElt(expected[idx])) {
+				t.Errorf("Wrong element going backwards. Expected: %d, Actual: %v", expected[idx], it.Value())
+			}
+			if !it.Remove() || it.Remove() {
+				t.Error("Remove should succeed once per element.")
+			}
+		}
+		if !sc.IsEmpty() {
+			t.Errorf("ReverseIterator should remove every element. Expected: %d, Actual: %d", 0, sc.Len())
+		}
+
+		sc.Add(IntElt(0))
+		it := sc.Iterator()
+		it.Next()
+		sc.Clear()
+		func() {
+			if Debug {
+				defer func() {
+					if r := recover(); r != ErrConcurrentModification {
+						t.Errorf("Next should panic in debug mode. Expected: %v, Actual: %v", ErrConcurrentModification, r)
+					}
+				}()
+			}
+			if it.Next() || it.Err() != ErrConcurrentModification {
+				t.Errorf("Next should fail after a modification. Expected: %v, Actual: %v", ErrConcurrentModification, it.Err())
+			}
+		}()
+	}
+}
@@ -0,0 +1,83 @@
+package stackadts_test
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+	"github.com/johnsrd7/go-adts/adtstest"
+	stackadts "github.com/johnsrd7/go-adts/stacks"
+)
+
+func TestSliceStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack { return stackadts.MakeSliceStack() })
+}
+
+func TestSliceStackThreadSafeConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack { return stackadts.MakeSliceStackThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestListStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack { return stackadts.MakeListStack() })
+}
+
+func TestListStackThreadSafeConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack { return stackadts.MakeListStackThreadSafe() }, adtstest.ThreadSafe())
+}
+
+func TestSyncStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack {
+		return stackadts.SynchronizedStack(stackadts.MakeListStack())
+	}, adtstest.ThreadSafe())
+}
+
+func TestObservableStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack { return stackadts.ObserveStack(stackadts.MakeSliceStack()) })
+}
+
+func TestInstrumentedStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack {
+		return stackadts.InstrumentStack(stackadts.MakeSliceStack(), adts.MakeMetrics())
+	})
+}
+
+func TestSliceStackLinearizability(t *testing.T) {
+	adtstest.RunStackLinearizability(t, func() stackadts.Stack { return stackadts.MakeSliceStackThreadSafe() })
+}
+
+func TestListStackLinearizability(t *testing.T) {
+	adtstest.RunStackLinearizability(t, func() stackadts.Stack { return stackadts.MakeListStackThreadSafe() })
+}
+
+func TestNewSliceStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack {
+		return stackadts.NewSliceStack(adts.WithThreadSafety(), adts.WithCapacity(8), adts.WithMetrics(adts.MakeMetrics()))
+	}, adtstest.ThreadSafe())
+}
+
+func TestNewListStackConformance(t *testing.T) {
+	adtstest.RunStackSuite(t, func() stackadts.Stack {
+		return stackadts.NewListStack(adts.WithThreadSafety(), adts.WithCapacity(8), adts.WithMetrics(adts.MakeMetrics()))
+	}, adtstest.ThreadSafe())
+}
+
+func TestSliceStackCapacityPolicyConformance(t *testing.T) {
+	for _, policy := range []adts.CapacityPolicy{adts.NeverShrink{}, adts.Hysteresis{ShrinkBelow: 0.25, Target: 0.5}, adts.FixedStep{Step: 4}, adts.MemoryBudget{MaxElements: 1 << 20}} {
+		adtstest.RunStackSuite(t, func() stackadts.Stack {
+			return stackadts.NewSliceStack(adts.WithCapacityPolicy(policy))
+		})
+	}
+}
+
+func TestRecycledListStackConformance(t *testing.T) {
+	r := adts.MakeNodeRecycler()
+	adtstest.RunStackSuite(t, func() stackadts.Stack {
+		return stackadts.NewListStack(adts.WithThreadSafety(), adts.WithNodeRecycler(r))
+	}, adtstest.ThreadSafe())
+}
+
+func TestRecycledListStackLinearizability(t *testing.T) {
+	r := adts.MakeNodeRecycler()
+	adtstest.RunStackLinearizability(t, func() stackadts.Stack {
+		return stackadts.NewListStack(adts.WithThreadSafety(), adts.WithNodeRecycler(r))
+	})
+}
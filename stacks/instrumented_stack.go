@@ -0,0 +1,93 @@
+package stackadts
+
+import (
+	"time"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// InstrumentedStack wraps any Stack and records the count and latency of every
+// operation, and the high-water mark of its length, in a adts.Metrics.
+type InstrumentedStack struct {
+	inner   Stack
+	metrics *adts.Metrics
+}
+
+// InstrumentStack wraps the given stack so that its operations are recorded in m.
+func InstrumentStack(s Stack, m *adts.Metrics) *InstrumentedStack {
+	return &InstrumentedStack{s, m}
+}
+
+// Metrics returns the metrics the wrapper records into.
+func (i *InstrumentedStack) Metrics() *adts.Metrics {
+	return i.metrics
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the stack.
+func (i *InstrumentedStack) Len() int {
+	defer i.metrics.ObserveOp(adts.OpLen, time.Now())
+	return i.inner.Len()
+}
+
+// IsEmpty returns if the stack is empty or not.
+func (i *InstrumentedStack) IsEmpty() bool {
+	defer i.metrics.ObserveOp(adts.OpIsEmpty, time.Now())
+	return i.inner.IsEmpty()
+}
+
+// Clear removes all elements from the stack.
+func (i *InstrumentedStack) Clear() {
+	defer i.metrics.ObserveOp(adts.OpClear, time.Now())
+	i.inner.Clear()
+}
+
+// Contains returns true if the given item is in the stack.
+func (i *InstrumentedStack) Contains(item adts.ContainerElement) bool {
+	defer i.metrics.ObserveOp(adts.OpContains, time.Now())
+	return i.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the stack.
+func (i *InstrumentedStack) Add(item adts.ContainerElement) bool {
+	start := time.Now()
+	added := i.inner.Add(item)
+	i.metrics.ObserveOp(adts.OpAdd, start)
+	i.metrics.ObserveLen(i.inner.Len())
+	return added
+}
+
+// Remove returns true if the given element was removed.
+func (i *InstrumentedStack) Remove(item adts.ContainerElement) bool {
+	defer i.metrics.ObserveOp(adts.OpRemove, time.Now())
+	return i.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (i *InstrumentedStack) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	defer i.metrics.ObserveOp(adts.OpRemove, time.Now())
+	return adts.RemoveElement(i.inner, item)
+}
+
+// -------------------------------------------------------
+// Stack Methods
+// -------------------------------------------------------
+
+// Push pushes the given element onto the top of the stack.
+func (i *InstrumentedStack) Push(item adts.ContainerElement) bool {
+	start := time.Now()
+	pushed := i.inner.Push(item)
+	i.metrics.ObserveOp(adts.OpPush, start)
+	i.metrics.ObserveLen(i.inner.Len())
+	return pushed
+}
+
+// Pop removes the top element from the stack and returns the element.
+func (i *InstrumentedStack) Pop() (adts.ContainerElement, bool) {
+	defer i.metrics.ObserveOp(adts.OpPop, time.Now())
+	return i.inner.Pop()
+}
@@ -0,0 +1,25 @@
+package stackadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestInstrumentStack(t *testing.T) {
+	m := adts.MakeMetrics()
+
+	var s Stack
+	s = InstrumentStack(MakeSliceStack(), m)
+	for i := 0; i < 10; i++ {
+		s.Push(adts.IntElt(i))
+	}
+	for i := 0; i < 5; i++ {
+		s.Pop()
+	}
+
+	stats := m.Stats()
+	if stats.Ops["Push"].Count != 10 || stats.Ops["Pop"].Count != 5 || stats.HighWater != 10 {
+		t.Errorf("Wrong stats: %v", stats)
+	}
+}
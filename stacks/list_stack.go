@@ -1,27 +1,49 @@
 package stackadts
 
 import (
-	"container/list"
 	"sync"
 
 	adts "github.com/johnsrd7/go-adts"
+	"github.com/johnsrd7/go-adts/internal/nodelist"
 )
 
 // ListStack is a simple type that implements the Stack interface (both threadsafe and not).
 type ListStack struct {
-	backer     *list.List
-	lock       *sync.Mutex
+	backer     *nodelist.List[adts.ContainerElement]
+	lock       *sync.RWMutex
 	threadSafe bool
+	metrics    *adts.Metrics
+	equal      adts.EqualFunc
+
+	// modCount counts structural changes so iterators can detect them.
+	modCount uint64
+}
+
+// NewListStack creates a new ListStack configured by the given options.
+// Capacity and ShrinkFactor don't apply and are ignored. With
+// adts.WithNodeRecycler the stack reuses the nodes of removed elements.
+func NewListStack(opts ...adts.Option) *ListStack {
+	o := adts.MakeOptions(opts...)
+	return &ListStack{nodelist.MakeList(o.Recycler), &sync.RWMutex{}, o.ThreadSafe, o.Metrics, o.Equal, 0}
 }
 
-// MakeListStack creates a non-threadsafe ListStack
-func MakeListStack() *ListStack {
-	return &ListStack{list.New(), &sync.Mutex{}, false}
+// MakeListStack creates a new non-threadsafe ListStack. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the stack.
+func MakeListStack(eq ...adts.EqualFunc) *ListStack {
+	return NewListStack(adts.WithEquality(firstEqual(eq)))
 }
 
-// MakeListStackThreadSafe creates a threadsafe ListStack
-func MakeListStackThreadSafe() *ListStack {
-	return &ListStack{list.New(), &sync.Mutex{}, true}
+// MakeListStackThreadSafe creates a new threadsafe ListStack. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the stack.
+func MakeListStackThreadSafe(eq ...adts.EqualFunc) *ListStack {
+	return NewListStack(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the stack, which then records how
+// long it waits on its lock. It must be called before the stack is shared
+// between goroutines.
+func (ls *ListStack) SetMetrics(m *adts.Metrics) {
+	ls.metrics = m
 }
 
 // -------------------------------------------------------
@@ -31,8 +53,8 @@ func MakeListStackThreadSafe() *ListStack {
 // Len returns the number of elements in the stack.
 func (ls *ListStack) Len() int {
 	if ls.threadSafe {
-		ls.lock.Lock()
-		defer ls.lock.Unlock()
+		ls.metrics.Lock(ls.lock.RLocker())
+		defer ls.lock.RUnlock()
 		return ls.backer.Len()
 	}
 
@@ -47,20 +69,26 @@ func (ls *ListStack) IsEmpty() bool {
 // Clear removes all elements from the stack.
 func (ls *ListStack) Clear() {
 	if ls.threadSafe {
-		ls.lock.Lock()
+		ls.metrics.Lock(ls.lock)
 		defer ls.lock.Unlock()
-		ls.backer.Init()
+		ls.clearHelper()
 		return
 	}
 
+	ls.clearHelper()
+}
+
+// clearHelper removes every element (in a non-threadsafe way).
+func (ls *ListStack) clearHelper() {
 	ls.backer.Init()
+	ls.modCount++
 }
 
 // Contains returns true if the given item is in the stack.
 func (ls *ListStack) Contains(item adts.ContainerElement) bool {
 	if ls.threadSafe {
-		ls.lock.Lock()
-		defer ls.lock.Unlock()
+		ls.metrics.Lock(ls.lock.RLocker())
+		defer ls.lock.RUnlock()
 		return ls.containsHelper(item)
 	}
 
@@ -70,10 +98,8 @@ func (ls *ListStack) Contains(item adts.ContainerElement) bool {
 // containsHelper checks the list for the given element (in a non-threadsafe way).
 func (ls *ListStack) containsHelper(item adts.ContainerElement) bool {
 	for tmp := ls.backer.Front(); tmp != nil; tmp = tmp.Next() {
-		if v, ok := tmp.Value.(adts.ContainerElement); ok {
-			if v.Equals(item) {
-				return true
-			}
+		if ls.equal.Equal(tmp.Elt, item) {
+			return true
 		}
 	}
 
@@ -83,18 +109,32 @@ func (ls *ListStack) containsHelper(item adts.ContainerElement) bool {
 // Add returns true if the given element was added to the top of the stack.
 func (ls *ListStack) Add(item adts.ContainerElement) bool {
 	if ls.threadSafe {
-		ls.lock.Lock()
+		ls.metrics.Lock(ls.lock)
 		defer ls.lock.Unlock()
-		return ls.backer.PushFront(item) != nil
+		return ls.addHelper(item)
 	}
 
-	return ls.backer.PushFront(item) != nil
+	return ls.addHelper(item)
+}
+
+// addHelper adds the element to the list (in a non-threadsafe way).
+func (ls *ListStack) addHelper(item adts.ContainerElement) bool {
+	ls.backer.PushFront(item)
+	ls.modCount++
+	return true
 }
 
 // Remove returns true if the given element was removed.
 func (ls *ListStack) Remove(item adts.ContainerElement) bool {
+	_, ok := ls.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (ls *ListStack) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
 	if ls.threadSafe {
-		ls.lock.Lock()
+		ls.metrics.Lock(ls.lock)
 		defer ls.lock.Unlock()
 		return ls.removeHelper(item)
 	}
@@ -102,17 +142,16 @@ func (ls *ListStack) Remove(item adts.ContainerElement) bool {
 	return ls.removeHelper(item)
 }
 
-func (ls *ListStack) removeHelper(item adts.ContainerElement) bool {
+func (ls *ListStack) removeHelper(item adts.ContainerElement) (adts.ContainerElement, bool) {
 	for tmp := ls.backer.Front(); tmp != nil; tmp = tmp.Next() {
-		if v, ok := tmp.Value.(adts.ContainerElement); ok {
-			if v.Equals(item) {
-				removed, ok := ls.backer.Remove(tmp).(adts.ContainerElement)
-				return ok && removed.Equals(item)
-			}
+		if ls.equal.Equal(tmp.Elt, item) {
+			elt := ls.backer.Remove(tmp)
+			ls.modCount++
+			return elt, true
 		}
 	}
 
-	return false
+	return adts.EmptyContainerElement{}, false
 }
 
 // -------------------------------------------------------
@@ -127,7 +166,7 @@ func (ls *ListStack) Push(item adts.ContainerElement) bool {
 // Pop removes the top element from the stack and returns the element.
 func (ls *ListStack) Pop() (adts.ContainerElement, bool) {
 	if ls.threadSafe {
-		ls.lock.Lock()
+		ls.metrics.Lock(ls.lock)
 		defer ls.lock.Unlock()
 		return ls.popHelper()
 	}
@@ -137,16 +176,385 @@ func (ls *ListStack) Pop() (adts.ContainerElement, bool) {
 
 // popHelper removes the element from the front of the list and returns the element.
 func (ls *ListStack) popHelper() (adts.ContainerElement, bool) {
-	// Have to use list.List's Len function since we might already
+	// Have to use the backing list's Len function since we might already
 	// be inside a lock and we don't want to deadlock.
 	if ls.backer.Len() == 0 {
 		return adts.EmptyContainerElement{}, false
 	}
 
-	if lastElt, ok := ls.backer.Front().Value.(adts.ContainerElement); ok {
-		ls.backer.Remove(ls.backer.Front())
-		return lastElt, true
+	lastElt := ls.backer.Remove(ls.backer.Front())
+	ls.modCount++
+	return lastElt, true
+}
+
+// -------------------------------------------------------
+// Relinking Methods
+// -------------------------------------------------------
+
+// Concat moves every element of other to the bottom of the stack, keeping
+// their order, so other's top ends up directly below this stack's bottom.
+// other is left empty. It takes O(1) time. Both stacks are locked (in
+// adts.LockOrder order) if they're threadsafe. It returns false if other is
+// the stack itself.
+func (ls *ListStack) Concat(other *ListStack) bool {
+	if other == ls {
+		return false
+	}
+
+	defer lockPair(ls, other)()
+
+	if other.backer.Len() == 0 {
+		return true
 	}
 
-	return adts.EmptyContainerElement{}, false
+	ls.backer.Append(other.backer)
+	ls.modCount++
+	other.modCount++
+	return true
+}
+
+// lockPair takes the write locks of both stacks (if threadsafe) in
+// adts.LockOrder order and returns a function that releases them.
+func lockPair(a, b *ListStack) func() {
+	first, second := a, b
+	if adts.LockOrder(b) < adts.LockOrder(a) {
+		first, second = b, a
+	}
+
+	for _, ls := range []*ListStack{first, second} {
+		if ls.threadSafe {
+			ls.metrics.Lock(ls.lock)
+		}
+	}
+
+	return func() {
+		for _, ls := range []*ListStack{second, first} {
+			if ls.threadSafe {
+				ls.lock.Unlock()
+			}
+		}
+	}
+}
+
+// SplitAt keeps the top idx elements on the stack and returns the ones below
+// them as a new stack with the same settings. It walks from whichever end of
+// the stack is closer to idx.
+func (ls *ListStack) SplitAt(idx int) *ListStack {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock)
+		defer ls.lock.Unlock()
+		return ls.splitAtHelper(idx)
+	}
+
+	return ls.splitAtHelper(idx)
+}
+
+// splitAtHelper moves the elements from idx on into a new stack.
+func (ls *ListStack) splitAtHelper(idx int) *ListStack {
+	rest := ls.emptyLike()
+	rest.backer = ls.backer.SplitAt(idx)
+	ls.modCount++
+	return rest
+}
+
+// Reverse reverses the order of the stack in place.
+func (ls *ListStack) Reverse() {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock)
+		defer ls.lock.Unlock()
+		ls.reverseHelper()
+		return
+	}
+
+	ls.reverseHelper()
+}
+
+// reverseHelper swaps the links of every node, so nothing is allocated.
+func (ls *ListStack) reverseHelper() {
+	ls.backer.Reverse()
+	ls.modCount++
+}
+
+// Rotate moves the top n elements to the bottom of the stack, keeping their
+// order. A negative n moves the bottom -n elements to the top instead.
+func (ls *ListStack) Rotate(n int) {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock)
+		defer ls.lock.Unlock()
+		ls.rotateHelper(n)
+		return
+	}
+
+	ls.rotateHelper(n)
+}
+
+// rotateHelper relinks the list into a ring and cuts it before the new
+// front, walking to it from whichever end is closer.
+func (ls *ListStack) rotateHelper(n int) {
+	n = rotation(n, ls.backer.Len())
+	if n == 0 {
+		return
+	}
+
+	ls.backer.Rotate(n)
+	ls.modCount++
+}
+
+// rotation reduces a rotation by n of a stack of the given length to the
+// equivalent rotation in [0, length).
+func rotation(n, length int) int {
+	if length == 0 {
+		return 0
+	}
+
+	n %= length
+	if n < 0 {
+		n += length
+	}
+
+	return n
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the stack from the top down. Its Err
+// returns adts.ErrConcurrentModification if the stack is changed other than
+// through the iterator's Remove.
+func (ls *ListStack) Iterator() adts.MutableIterator {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock.RLocker())
+		defer ls.lock.RUnlock()
+	}
+
+	return &listStackIterator{ls: ls, expected: ls.modCount}
+}
+
+// listStackIterator is the fail-fast iterator of a ListStack. It remembers the
+// element after the current one so the current one can be removed, and copies
+// the current element so Value never reads a recycled node.
+type listStackIterator struct {
+	ls        *ListStack
+	cur       *nodelist.Node[adts.ContainerElement]
+	next      *nodelist.Node[adts.ContainerElement]
+	val       adts.ContainerElement
+	started   bool
+	expected  uint64
+	removable bool
+	err       error
+}
+
+// Next moves to the next element and returns false once there are none or
+// the stack has been changed.
+func (it *listStackIterator) Next() bool {
+	if it.ls.threadSafe {
+		it.ls.metrics.Lock(it.ls.lock.RLocker())
+		defer it.ls.lock.RUnlock()
+	}
+
+	it.removable = false
+	if it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.ls.modCount); it.err != nil {
+		return false
+	}
+
+	if !it.started {
+		it.next, it.started = it.ls.backer.Front(), true
+	}
+	if it.cur = it.next; it.cur == nil {
+		it.val = nil
+		return false
+	}
+
+	it.next, it.val = it.cur.Next(), it.cur.Elt
+	it.removable = true
+	return true
+}
+
+// Value returns the current element.
+func (it *listStackIterator) Value() adts.ContainerElement {
+	if it.val == nil {
+		return adts.EmptyContainerElement{}
+	}
+
+	return it.val
+}
+
+// Err returns adts.ErrConcurrentModification if the stack was changed during
+// iteration.
+func (it *listStackIterator) Err() error {
+	return it.err
+}
+
+// Remove removes the current element from the stack.
+func (it *listStackIterator) Remove() bool {
+	if it.ls.threadSafe {
+		it.ls.metrics.Lock(it.ls.lock)
+		defer it.ls.lock.Unlock()
+	}
+
+	if !it.removable || it.err != nil {
+		return false
+	}
+	if it.err = adts.CheckModCount(it.expected, it.ls.modCount); it.err != nil {
+		return false
+	}
+
+	// The node goes back to the recycler, so don't keep it.
+	it.ls.backer.Remove(it.cur)
+	it.cur = nil
+	it.ls.modCount++
+	it.expected = it.ls.modCount
+	it.removable = false
+	return true
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the stack with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (ls *ListStack) Clone() *ListStack {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock.RLocker())
+		defer ls.lock.RUnlock()
+		return ls.cloneHelper()
+	}
+
+	return ls.cloneHelper()
+}
+
+// cloneHelper copies the stack element by element without locking.
+func (ls *ListStack) cloneHelper() *ListStack {
+	clone := ls.emptyLike()
+	for tmp := ls.backer.Front(); tmp != nil; tmp = tmp.Next() {
+		clone.backer.PushBack(adts.CloneElement(tmp.Elt))
+	}
+
+	return clone
+}
+
+// emptyLike returns an empty stack with the same settings as this one.
+func (ls *ListStack) emptyLike() *ListStack {
+	return &ListStack{nodelist.MakeList(ls.backer.Recycler()), &sync.RWMutex{}, ls.threadSafe, ls.metrics, ls.equal, 0}
+}
+
+// Snapshot returns a copy of the stack's elements from the top down. The
+// elements themselves aren't cloned.
+func (ls *ListStack) Snapshot() []adts.ContainerElement {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock.RLocker())
+		defer ls.lock.RUnlock()
+		return ls.snapshotHelper()
+	}
+
+	return ls.snapshotHelper()
+}
+
+// snapshotHelper copies the elements into a slice without locking.
+func (ls *ListStack) snapshotHelper() []adts.ContainerElement {
+	elts := make([]adts.ContainerElement, 0, ls.backer.Len())
+	for tmp := ls.backer.Front(); tmp != nil; tmp = tmp.Next() {
+		elts = append(elts, tmp.Elt)
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the stack from the top down.
+// The lock (if threadsafe) is only held while the snapshot is copied.
+func (ls *ListStack) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(ls.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// unlocked returns a non-threadsafe view of the stack that shares its backing list.
+func (ls *ListStack) unlocked() *ListStack {
+	return &ListStack{ls.backer, ls.lock, false, ls.metrics, ls.equal, ls.modCount}
+}
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the stack. Changes made before fn returns an error
+// are kept.
+func (ls *ListStack) Update(fn func(tx Stack) error) error {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock)
+		defer ls.lock.Unlock()
+		return ls.updateHelper(fn)
+	}
+
+	return ls.updateHelper(fn)
+}
+
+// updateHelper runs fn against a view of the stack and then copies the view's
+// modification count back.
+func (ls *ListStack) updateHelper(fn func(tx Stack) error) error {
+	view := ls.unlocked()
+	defer func() {
+		ls.modCount = view.modCount
+	}()
+	return fn(view)
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the stack. fn must not modify the view.
+func (ls *ListStack) View(fn func(tx Stack) error) error {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock.RLocker())
+		defer ls.lock.RUnlock()
+		return fn(ls.unlocked())
+	}
+
+	return fn(ls.unlocked())
+}
+
+// Atomically runs fn as a single atomic operation on the stack.
+func (ls *ListStack) Atomically(fn func(tx adts.Container) error) error {
+	return ls.Update(func(tx Stack) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent pushes the given element only if it isn't already in the
+// stack and returns whether it was added.
+func (ls *ListStack) AddIfAbsent(item adts.ContainerElement) bool {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock)
+		defer ls.lock.Unlock()
+		return !ls.containsHelper(item) && ls.addHelper(item)
+	}
+
+	return !ls.containsHelper(item) && ls.addHelper(item)
+}
+
+// PopIf removes the top element from the stack only if it matches the given
+// predicate, and returns the element.
+func (ls *ListStack) PopIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	if ls.threadSafe {
+		ls.metrics.Lock(ls.lock)
+		defer ls.lock.Unlock()
+		return ls.popIfHelper(pred)
+	}
+
+	return ls.popIfHelper(pred)
+}
+
+// popIfHelper peeks at the front of the list and only pops it if it matches.
+func (ls *ListStack) popIfHelper(pred adts.Predicate) (adts.ContainerElement, bool) {
+	if ls.backer.Len() == 0 {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	if !pred(ls.backer.Front().Elt) {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return ls.popHelper()
 }
@@ -101,7 +101,7 @@ func TestListStackAdd(t *testing.T) {
 		for j := 0; j < len(vals); j++ {
 			idx := len(vals) - 1 - j
 			v = vals[idx]
-			iterVal, ok := iter.Value.(adts.IntElt)
+			iterVal, ok := iter.Elt.(adts.IntElt)
 			if !ok || !iterVal.Equals(adts.IntElt(v)) {
 				t.Errorf("Add failed to add the value to the proper index | (idx,val) - Expected: (%d, %d), Actual: (%d, %v)",
 					idx, v, j, iterVal)
@@ -147,7 +147,7 @@ func TestListStackRemove(t *testing.T) {
 		for j := 0; j < int(idx); j++ {
 			tmp = tmp.Next()
 		}
-		val, _ := tmp.Value.(adts.IntElt)
+		val, _ := tmp.Elt.(adts.IntElt)
 		if !stack.Remove(val) {
 			t.Errorf("Failed to remove index %d from list.", idx)
 			return
@@ -202,7 +202,7 @@ func TestListStackPush(t *testing.T) {
 		for j := 0; j < len(expected); j++ {
 			idx := len(expected) - 1 - j
 			exp := expected[idx]
-			act, ok := iter.Value.(adts.IntElt)
+			act, ok := iter.Elt.(adts.IntElt)
 			if !ok || !act.Equals(adts.IntElt(exp)) {
 				t.Errorf("Stack order was ruined by push #%d. (idx, val) - Expected: (%d, %d), Actual: (%d, %v)",
 					i+1, idx, exp, j, act)
@@ -244,7 +244,7 @@ func listStackToString(ls *ListStack) string {
 	res := "["
 	tmp := ls.backer.Front()
 	if tmp != nil {
-		res += fmt.Sprintf("%v", tmp.Value)
+		res += fmt.Sprintf("%v", tmp.Elt)
 		tmp = tmp.Next()
 	}
 
@@ -253,9 +253,316 @@ func listStackToString(ls *ListStack) string {
 			break
 		}
 
-		res += fmt.Sprintf(",%v", tmp.Value)
+		res += fmt.Sprintf(",%v", tmp.Elt)
 		tmp = tmp.Next()
 	}
 
 	return res + "]"
 }
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestListStackPopIf(t *testing.T) {
+	var a adts.Atomic
+	a = MakeListStackThreadSafe()
+
+	stack := a.(*ListStack)
+	for i := 0; i < 10; i++ {
+		stack.Push(adts.IntElt(i))
+	}
+
+	even := func(elt adts.ContainerElement) bool {
+		return elt.(adts.IntElt)%2 == 0
+	}
+
+	if _, ok := stack.PopIf(even); ok {
+		t.Error("PopIf should not pop an element that doesn't match.")
+	}
+	stack.Pop()
+	if popped, ok := stack.PopIf(even); !ok || !popped.Equals(adts.IntElt(8)) {
+		t.Errorf("PopIf should pop a matching element. Expected: %v, Actual: %v", 8, popped)
+	}
+	if stack.Len() != 8 {
+		t.Errorf("Expected length: %d, Actual length: %d", 8, stack.Len())
+	}
+	if stack.AddIfAbsent(adts.IntElt(0)) || !stack.AddIfAbsent(adts.IntElt(20)) {
+		t.Error("AddIfAbsent should only add elements that aren't in the stack.")
+	}
+}
+
+func TestListStackEqualFunc(t *testing.T) {
+	lastDigit := adts.EqualBy(func(e adts.ContainerElement) int {
+		i, _ := e.(adts.IntElt)
+		return int(i) % 10
+	})
+
+	for _, c := range []*ListStack{MakeListStack(lastDigit), MakeListStackThreadSafe(lastDigit)} {
+		c.Push(adts.IntElt(1))
+		c.Push(adts.IntElt(2))
+
+		if !c.Contains(adts.IntElt(11)) || c.Contains(adts.IntElt(3)) {
+			t.Error("Contains should use the EqualFunc.")
+		}
+		if !c.Remove(adts.IntElt(12)) || c.Len() != 1 || c.Contains(adts.IntElt(2)) {
+			t.Error("Remove should use the EqualFunc.")
+		}
+		if c.AddIfAbsent(adts.IntElt(21)) {
+			t.Error("AddIfAbsent should use the EqualFunc.")
+		}
+	}
+}
+
+// testStackClone checks that clones and snapshots of the given stack are
+// independent of it and ordered from the top down.
+func testStackClone(t *testing.T, stack Stack, clone func(Stack) Stack) {
+	stack.Push(adts.IntElt(1))
+	stack.Push(adts.Bytes{2})
+
+	c := clone(stack)
+	top, _ := c.Pop()
+	top.(adts.Bytes)[0] = 9
+	if stack.Len() != 2 || !stack.Contains(adts.Bytes{2}) {
+		t.Error("Changing the clone changed the original.")
+	}
+
+	snapshot := stack.(adts.Snapshotter).Snapshot()
+	if len(snapshot) != 2 || !snapshot[0].Equals(adts.Bytes{2}) || !snapshot[1].Equals(adts.IntElt(1)) {
+		t.Errorf("Snapshot should be ordered from the top down. Actual: %v", snapshot)
+	}
+
+	it := stack.(adts.Snapshotter).SnapshotIterator()
+	stack.Clear()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("SnapshotIterator saw a later change. Expected: %d, Actual: %d", 2, count)
+	}
+}
+
+func TestListStackClone(t *testing.T) {
+	testStackClone(t, MakeListStackThreadSafe(), func(s Stack) Stack { return s.(*ListStack).Clone() })
+}
+
+type iterable interface {
+	Iterator() adts.MutableIterator
+}
+
+// testIterator fills c with 0 to 4, checks that its iterator visits them in
+// the given order and can remove the even ones, and that it fails fast.
+func testIterator(t *testing.T, c adts.Container, order []int) {
+	for i := 0; i < 5; i++ {
+		c.Add(adts.IntElt(i))
+	}
+
+	idx := 0
+	it := c.(iterable).Iterator()
+	for it.Next() {
+		if !it.Value().Equals(adts.IntElt(order[idx])) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", order[idx], it.Value())
+		}
+		if order[idx]%2 == 0 && (!it.Remove() || it.Remove()) {
+			t.Error("Remove should succeed once per element.")
+		}
+		idx++
+	}
+	if idx != len(order) || it.Err() != nil {
+		t.Errorf("Iterator should visit every element. Expected: %d, Actual: %d (%v)", len(order), idx, it.Err())
+	}
+	if c.Len() != 2 || !c.Contains(adts.IntElt(1)) || !c.Contains(adts.IntElt(3)) {
+		t.Errorf("Remove should remove the even elements. Expected length: %d, Actual length: %d", 2, c.Len())
+	}
+
+	it = c.(iterable).Iterator()
+	it.Next()
+	c.Add(adts.IntElt(5))
+	expectConcurrentModification(t, it)
+	if it.Remove() {
+		t.Error("Remove should fail after a concurrent modification.")
+	}
+}
+
+// expectConcurrentModification checks that Next fails fast, which panics in
+// debug builds.
+func expectConcurrentModification(t *testing.T, it adts.MutableIterator) {
+	if adts.Debug {
+		defer func() {
+			if r := recover(); r != adts.ErrConcurrentModification {
+				t.Errorf("Next should panic in debug mode. Expected: %v, Actual: %v", adts.ErrConcurrentModification, r)
+			}
+		}()
+	}
+
+	if it.Next() || it.Err() != adts.ErrConcurrentModification {
+		t.Errorf("Next should fail after a modification. Expected: %v, Actual: %v", adts.ErrConcurrentModification, it.Err())
+	}
+}
+
+func TestListStackIterator(t *testing.T) {
+	testIterator(t, MakeListStack(), []int{4, 3, 2, 1, 0})
+}
+
+func TestListStackThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeListStackThreadSafe(), []int{4, 3, 2, 1, 0})
+}
+
+// makeListStackOf builds a ListStack whose elements, in iteration order, are elts.
+func makeListStackOf(threadSafe bool, elts ...int) *ListStack {
+	c := MakeListStack()
+	if threadSafe {
+		c = MakeListStackThreadSafe()
+	}
+	for _, elt := range elts {
+		c.backer.PushBack(adts.IntElt(elt))
+	}
+
+	return c
+}
+
+// checkListStack checks that the elements, in iteration order, are exactly expected.
+func checkListStack(t *testing.T, c *ListStack, expected ...int) {
+	t.Helper()
+
+	actual := c.Snapshot()
+	if len(actual) != len(expected) {
+		t.Errorf("Wrong elements. Expected: %v, Actual: %v", expected, actual)
+		return
+	}
+	for i, elt := range actual {
+		if !elt.Equals(adts.IntElt(expected[i])) {
+			t.Errorf("Wrong elements. Expected: %v, Actual: %v", expected, actual)
+			return
+		}
+	}
+}
+
+func TestListStackConcat(t *testing.T) {
+	c := makeListStackOf(true, 0, 1)
+	other := makeListStackOf(true, 2, 3)
+
+	if !c.Concat(other) {
+		t.Fatal("Concat should succeed.")
+	}
+	checkListStack(t, c, 0, 1, 2, 3)
+	checkListStack(t, other)
+
+	if c.Concat(c) {
+		t.Error("Concat with itself should fail.")
+	}
+}
+
+func TestListStackSplitAt(t *testing.T) {
+	c := makeListStackOf(false, 0, 1, 2, 3)
+
+	rest := c.SplitAt(1)
+	checkListStack(t, c, 0)
+	checkListStack(t, rest, 1, 2, 3)
+
+	checkListStack(t, rest.SplitAt(3))
+	checkListStack(t, rest.SplitAt(0), 1, 2, 3)
+	checkListStack(t, rest)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SplitAt past the end should panic.")
+		}
+	}()
+	c.SplitAt(2)
+}
+
+func TestListStackReverseRotate(t *testing.T) {
+	c := makeListStackOf(true, 0, 1, 2, 3)
+
+	c.Reverse()
+	checkListStack(t, c, 3, 2, 1, 0)
+
+	c.Rotate(1)
+	checkListStack(t, c, 2, 1, 0, 3)
+
+	c.Rotate(-5)
+	checkListStack(t, c, 3, 2, 1, 0)
+
+	empty := makeListStackOf(false)
+	empty.Reverse()
+	empty.Rotate(2)
+	checkListStack(t, empty)
+}
+
+func TestListStackConcatOrder(t *testing.T) {
+	stack, other := MakeListStack(), MakeListStack()
+	stack.Push(adts.IntElt(1))
+	other.Push(adts.IntElt(2))
+	stack.Concat(other)
+
+	for _, expected := range []int{1, 2} {
+		if elt, _ := stack.Pop(); !elt.Equals(adts.IntElt(expected)) {
+			t.Errorf("other should end up below the stack. Expected: %d, Actual: %v", expected, elt)
+		}
+	}
+}
+
+func TestListStackRecyclerAllocs(t *testing.T) {
+	stack := NewListStack(adts.WithNodeRecycler(adts.MakeNodeRecycler()))
+	var elt adts.ContainerElement = adts.IntElt(1)
+	stack.Push(elt)
+	stack.Pop()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		stack.Push(elt)
+		stack.Pop()
+	})
+	if allocs != 0 {
+		t.Errorf("Push after Pop should reuse the node. Expected: %d, Actual: %v", 0, allocs)
+	}
+}
+
+func TestListStackIteratorRemoveRecycled(t *testing.T) {
+	r := adts.MakeNodeRecycler()
+	stack := NewListStack(adts.WithThreadSafety(), adts.WithNodeRecycler(r))
+	stack.Push(adts.IntElt(1))
+	stack.Push(adts.IntElt(1))
+
+	it := stack.Iterator()
+	if !it.Next() || !it.Remove() {
+		t.Fatal("The iterator should remove the first element.")
+	}
+
+	// Another stack may take the removed node straight from the recycler.
+	other := NewListStack(adts.WithNodeRecycler(r))
+	for i := 0; i < 10; i++ {
+		other.Push(adts.IntElt(2))
+	}
+
+	if !it.Value().Equals(adts.IntElt(1)) {
+		t.Errorf("Value changed after Remove. Expected: %d, Actual: %v", 1, it.Value())
+	}
+}
+
+func BenchmarkListStackPushPop(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		opts []adts.Option
+	}{
+		{"NoRecycler", nil},
+		{"Recycler", []adts.Option{adts.WithNodeRecycler(adts.MakeNodeRecycler())}},
+		{"ThreadSafeRecycler", []adts.Option{adts.WithThreadSafety(), adts.WithNodeRecycler(adts.MakeNodeRecycler())}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			stack := NewListStack(bc.opts...)
+			var elt adts.ContainerElement = adts.IntElt(1)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 64; j++ {
+					stack.Push(elt)
+				}
+				for j := 0; j < 64; j++ {
+					stack.Pop()
+				}
+			}
+		})
+	}
+}
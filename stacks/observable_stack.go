@@ -0,0 +1,111 @@
+package stackadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// ObservableStack wraps any Stack and reports every change made through it to the
+// registered listeners. It has the same ordering guarantees as
+// adts.ObservableContainer: changes are serialized by the wrapper and
+// listeners are called synchronously, in order, before the changing method
+// returns.
+type ObservableStack struct {
+	inner Stack
+	lock  *sync.Mutex
+
+	*adts.Notifier
+}
+
+// ObserveStack wraps the given stack so that its changes can be observed.
+func ObserveStack(s Stack) *ObservableStack {
+	return &ObservableStack{s, &sync.Mutex{}, adts.MakeNotifier()}
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the stack.
+func (o *ObservableStack) Len() int {
+	return o.inner.Len()
+}
+
+// IsEmpty returns if the stack is empty or not.
+func (o *ObservableStack) IsEmpty() bool {
+	return o.inner.IsEmpty()
+}
+
+// Clear removes all elements from the stack.
+func (o *ObservableStack) Clear() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.inner.Clear()
+	o.Notify(adts.Event{Kind: adts.Cleared, Index: -1})
+}
+
+// Contains returns true if the given item is in the stack.
+func (o *ObservableStack) Contains(item adts.ContainerElement) bool {
+	return o.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the stack.
+func (o *ObservableStack) Add(item adts.ContainerElement) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.inner.Add(item) {
+		return false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Added, Elt: item, Index: -1})
+	return true
+}
+
+// Remove returns true if the given element was removed.
+func (o *ObservableStack) Remove(item adts.ContainerElement) bool {
+	_, ok := o.RemoveElement(item)
+	return ok
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (o *ObservableStack) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	elt, ok := adts.RemoveElement(o.inner, item)
+	if !ok {
+		return elt, false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Removed, Elt: elt, Index: -1})
+	return elt, true
+}
+
+// -------------------------------------------------------
+// Stack Methods
+// -------------------------------------------------------
+
+// Push pushes the given element onto the top of the stack.
+func (o *ObservableStack) Push(item adts.ContainerElement) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if !o.inner.Push(item) {
+		return false
+	}
+
+	o.Notify(adts.Event{Kind: adts.Pushed, Elt: item, Index: -1})
+	return true
+}
+
+// Pop removes the top element from the stack and returns the element.
+func (o *ObservableStack) Pop() (adts.ContainerElement, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	popped, ok := o.inner.Pop()
+	if ok {
+		o.Notify(adts.Event{Kind: adts.Popped, Elt: popped, Index: -1})
+	}
+
+	return popped, ok
+}
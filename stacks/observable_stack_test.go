@@ -0,0 +1,58 @@
+package stackadts
+
+import (
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestObserveStack(t *testing.T) {
+	var s Stack
+	obs := ObserveStack(MakeListStack())
+	s = obs
+
+	events := []adts.Event{}
+	obs.Subscribe(func(e adts.Event) {
+		events = append(events, e)
+	})
+
+	s.Push(adts.IntElt(1))
+	s.Pop()
+	s.Pop()
+
+	expected := []adts.Event{
+		{Kind: adts.Pushed, Elt: adts.IntElt(1), Index: -1},
+		{Kind: adts.Popped, Elt: adts.IntElt(1), Index: -1},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, Actual: %d", len(expected), len(events))
+	}
+	for idx, e := range expected {
+		if events[idx] != e {
+			t.Errorf("Wrong event #%d. Expected: %v, Actual: %v", idx, e, events[idx])
+		}
+	}
+}
+
+func TestObserveStackRemoveReportsRemovedElement(t *testing.T) {
+	lastDigit := adts.EqualBy(func(e adts.ContainerElement) int {
+		i, _ := e.(adts.IntElt)
+		return int(i) % 10
+	})
+
+	for _, c := range []Stack{
+		MakeSliceStack(lastDigit),
+		MakeListStack(lastDigit),
+	} {
+		o := ObserveStack(SynchronizedStack(InstrumentStack(c, adts.MakeMetrics())))
+		var removed adts.ContainerElement
+		o.Subscribe(func(e adts.Event) {
+			removed = e.Elt
+		})
+
+		o.Add(adts.IntElt(11))
+		if !o.Remove(adts.IntElt(21)) || removed == nil || !removed.Equals(adts.IntElt(11)) {
+			t.Errorf("%T: the event should carry the removed element. Expected: %v, Actual: %v", c, 11, removed)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package stackadts
+
+import (
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// consNode is a cell of the immutable linked list behind a PersistentStack.
+// Nodes are never changed once they're created, so any number of stacks can
+// share them.
+type consNode struct {
+	elt  adts.ContainerElement
+	next *consNode
+}
+
+// PersistentStack is an immutable stack. Push and Pop return a new stack in
+// O(1) and leave the original unchanged, so old versions stay valid and
+// cost nothing to keep, which makes it a good fit for undo histories and
+// backtracking. It's safe to share between goroutines without locking.
+type PersistentStack struct {
+	top   *consNode
+	len   int
+	equal adts.EqualFunc
+}
+
+// NewPersistentStack creates an empty PersistentStack configured by the given
+// options. Only the equality option applies; a PersistentStack never needs
+// locking.
+func NewPersistentStack(opts ...adts.Option) *PersistentStack {
+	o := adts.MakeOptions(opts...)
+	return &PersistentStack{nil, 0, o.Equal}
+}
+
+// MakePersistentStack creates an empty PersistentStack. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the stack.
+func MakePersistentStack(eq ...adts.EqualFunc) *PersistentStack {
+	return NewPersistentStack(adts.WithEquality(firstEqual(eq)))
+}
+
+// Len returns the number of elements in the stack.
+func (ps *PersistentStack) Len() int {
+	return ps.len
+}
+
+// IsEmpty returns if the stack is empty or not.
+func (ps *PersistentStack) IsEmpty() bool {
+	return ps.len == 0
+}
+
+// Contains returns true if the given item is in the stack.
+func (ps *PersistentStack) Contains(item adts.ContainerElement) bool {
+	for tmp := ps.top; tmp != nil; tmp = tmp.next {
+		if ps.equal.Equal(tmp.elt, item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Peek returns the top element of the stack without removing it.
+func (ps *PersistentStack) Peek() (adts.ContainerElement, bool) {
+	if ps.top == nil {
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return ps.top.elt, true
+}
+
+// Push returns a new stack with the given element on top.
+func (ps *PersistentStack) Push(item adts.ContainerElement) *PersistentStack {
+	return &PersistentStack{&consNode{item, ps.top}, ps.len + 1, ps.equal}
+}
+
+// Pop returns the top element and a new stack without it. Popping an empty
+// stack returns the stack itself and false.
+func (ps *PersistentStack) Pop() (adts.ContainerElement, *PersistentStack, bool) {
+	if ps.top == nil {
+		return adts.EmptyContainerElement{}, ps, false
+	}
+
+	return ps.top.elt, &PersistentStack{ps.top.next, ps.len - 1, ps.equal}, true
+}
+
+// Remove returns a new stack without the topmost element equal to the given
+// item, and whether an element was removed. The elements below the removed
+// one are shared; the ones above it are copied.
+func (ps *PersistentStack) Remove(item adts.ContainerElement) (*PersistentStack, bool) {
+	var above []adts.ContainerElement
+	for tmp := ps.top; tmp != nil; tmp = tmp.next {
+		if !ps.equal.Equal(tmp.elt, item) {
+			above = append(above, tmp.elt)
+			continue
+		}
+
+		ret := &PersistentStack{tmp.next, ps.len - len(above) - 1, ps.equal}
+		for i := len(above) - 1; i >= 0; i-- {
+			ret = ret.Push(above[i])
+		}
+		return ret, true
+	}
+
+	return ps, false
+}
+
+// Clear returns an empty stack that uses the same equality as this one.
+func (ps *PersistentStack) Clear() *PersistentStack {
+	return &PersistentStack{nil, 0, ps.equal}
+}
+
+// ToListStack returns a new non-threadsafe ListStack with the same elements
+// in the same order.
+func (ps *PersistentStack) ToListStack() *ListStack {
+	ls := NewListStack(adts.WithEquality(ps.equal))
+	for tmp := ps.top; tmp != nil; tmp = tmp.next {
+		ls.backer.PushBack(tmp.elt)
+	}
+
+	return ls
+}
+
+// Persistent returns a PersistentStack holding the stack's current elements
+// in the same order. The stack is read under its read lock (if threadsafe).
+func (ls *ListStack) Persistent() *PersistentStack {
+	ps := NewPersistentStack(adts.WithEquality(ls.equal))
+	ls.View(func(tx Stack) error {
+		view := tx.(*ListStack)
+		for tmp := view.backer.Back(); tmp != nil; tmp = tmp.Prev() {
+			ps = ps.Push(tmp.Elt)
+		}
+		return nil
+	})
+
+	return ps
+}
@@ -0,0 +1,112 @@
+package stackadts
+
+import (
+	"math/rand"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// persistentStackElements pops every element off a copy of the stack.
+func persistentStackElements(ps *PersistentStack) []adts.ContainerElement {
+	var elts []adts.ContainerElement
+	for elt, rest, ok := ps.Pop(); ok; elt, rest, ok = rest.Pop() {
+		elts = append(elts, elt)
+	}
+
+	return elts
+}
+
+func TestPersistentStackPushPop(t *testing.T) {
+	empty := MakePersistentStack()
+	if _, rest, ok := empty.Pop(); ok || rest != empty {
+		t.Error("Pop on an empty stack should fail.")
+	}
+
+	one := empty.Push(adts.IntElt(1))
+	two := one.Push(adts.IntElt(2))
+	branch := one.Push(adts.IntElt(3))
+
+	if top, _ := two.Peek(); !top.Equals(adts.IntElt(2)) || two.Len() != 2 {
+		t.Errorf("Wrong top. Expected: %d, Actual: %v", 2, top)
+	}
+	if top, _ := branch.Peek(); !top.Equals(adts.IntElt(3)) || one.Len() != 1 || !empty.IsEmpty() {
+		t.Error("Pushing onto an old version changed another version.")
+	}
+
+	elt, rest, ok := two.Pop()
+	if !ok || !elt.Equals(adts.IntElt(2)) || rest.Len() != 1 || two.Len() != 2 {
+		t.Errorf("Pop went wrong. Actual: %v, %d", elt, rest.Len())
+	}
+}
+
+func TestPersistentStackRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(99))
+
+	// Keep every version alongside a copy of what it should hold.
+	versions := []*PersistentStack{MakePersistentStack()}
+	models := [][]adts.ContainerElement{nil}
+
+	for i := 0; i < 2000; i++ {
+		v := r.Intn(len(versions))
+		ps, model := versions[v], models[v]
+
+		switch r.Intn(3) {
+		case 0:
+			ps = ps.Push(adts.IntElt(i))
+			model = append([]adts.ContainerElement{adts.IntElt(i)}, model...)
+		case 1:
+			if _, rest, ok := ps.Pop(); ok {
+				ps, model = rest, model[1:]
+			}
+		case 2:
+			if len(model) > 0 {
+				idx := r.Intn(len(model))
+				rest, removed := ps.Remove(model[idx])
+				if !removed {
+					t.Fatalf("Remove of %v failed.", model[idx])
+				}
+				ps = rest
+				model = append(append([]adts.ContainerElement{}, model[:idx]...), model[idx+1:]...)
+			}
+		}
+
+		versions = append(versions, ps)
+		models = append(models, model)
+	}
+
+	for v, ps := range versions {
+		elts := persistentStackElements(ps)
+		if len(elts) != len(models[v]) || ps.Len() != len(models[v]) {
+			t.Fatalf("Version %d has the wrong length. Expected: %d, Actual: %d", v, len(models[v]), len(elts))
+		}
+		for i := range elts {
+			if !elts[i].Equals(models[v][i]) {
+				t.Fatalf("Version %d is wrong at %d. Expected: %v, Actual: %v", v, i, models[v][i], elts[i])
+			}
+		}
+	}
+}
+
+func TestPersistentStackConversion(t *testing.T) {
+	ls := MakeListStackThreadSafe()
+	for i := 0; i < 5; i++ {
+		ls.Push(adts.IntElt(i))
+	}
+
+	ps := ls.Persistent()
+	ls.Pop()
+	if ps.Len() != 5 || !ps.Contains(adts.IntElt(4)) {
+		t.Error("Changing the ListStack changed its persistent copy.")
+	}
+
+	back := ps.ToListStack()
+	for i := 4; i >= 0; i-- {
+		if elt, ok := back.Pop(); !ok || !elt.Equals(adts.IntElt(i)) {
+			t.Errorf("Wrong element. Expected: %d, Actual: %v", i, elt)
+		}
+	}
+	if ps.Len() != 5 {
+		t.Error("Changing the ListStack changed the PersistentStack it came from.")
+	}
+}
@@ -9,14 +9,28 @@ type SliceStack struct {
 	backer *adts.SliceContainer
 }
 
-// MakeSliceStack creates a non-threadsafe SliceStack
-func MakeSliceStack() *SliceStack {
-	return &SliceStack{adts.MakeSliceContainer()}
+// NewSliceStack creates a new SliceStack configured by the given options.
+func NewSliceStack(opts ...adts.Option) *SliceStack {
+	return &SliceStack{adts.NewSliceContainer(opts...)}
 }
 
-// MakeSliceStackThreadSafe creates a threadsafe SliceStack
-func MakeSliceStackThreadSafe() *SliceStack {
-	return &SliceStack{adts.MakeSliceContainerThreadSafe()}
+// MakeSliceStack creates a new non-threadsafe SliceStack. An optional EqualFunc
+// replaces ContainerElement.Equals when searching the stack.
+func MakeSliceStack(eq ...adts.EqualFunc) *SliceStack {
+	return NewSliceStack(adts.WithEquality(firstEqual(eq)))
+}
+
+// MakeSliceStackThreadSafe creates a new threadsafe SliceStack. An optional
+// EqualFunc replaces ContainerElement.Equals when searching the stack.
+func MakeSliceStackThreadSafe(eq ...adts.EqualFunc) *SliceStack {
+	return NewSliceStack(adts.WithThreadSafety(), adts.WithEquality(firstEqual(eq)))
+}
+
+// SetMetrics attaches the given metrics to the stack, which then records how
+// long it waits on its lock and when it shrinks its backing slice. It must be
+// called before the stack is shared between goroutines.
+func (ss *SliceStack) SetMetrics(m *adts.Metrics) {
+	ss.backer.Metrics = m
 }
 
 // -------------------------------------------------------
@@ -53,6 +67,12 @@ func (ss *SliceStack) Remove(item adts.ContainerElement) bool {
 	return ss.backer.Remove(item)
 }
 
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (ss *SliceStack) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	return ss.backer.RemoveElement(item)
+}
+
 // -------------------------------------------------------
 // Stack Methods
 // -------------------------------------------------------
@@ -64,29 +84,140 @@ func (ss *SliceStack) Push(item adts.ContainerElement) bool {
 
 // Pop removes the top element from the stack and returns the element.
 func (ss *SliceStack) Pop() (adts.ContainerElement, bool) {
-	// We want to reuse the Remove method from the SliceContainer class.
-	// The problem is that we need to get the last element in a threadsafe way
-	// (if needed) and then call remove. However, if we lock and then call
-	// Remove, that will also lock, which causes a deadlock. So we need to
-	// do our own locking here and then remove the element and then unlock.
-	if ss.Len() == 0 {
+	// We want to reuse the RemoveAtIndex method from the SliceContainer class,
+	// which doesn't lock. The emptiness check has to happen under the same lock
+	// as the removal, otherwise a concurrent Pop could empty the stack in
+	// between the two.
+	if ss.backer.ThreadSafe {
+		ss.backer.Metrics.Lock(ss.backer.Lock)
+		defer ss.backer.Lock.Unlock()
+		return ss.popHelper()
+	}
+
+	return ss.popHelper()
+}
+
+// popHelper removes the top element from the stack (in a non-threadsafe way).
+func (ss *SliceStack) popHelper() (adts.ContainerElement, bool) {
+	last := len(ss.backer.Backer) - 1
+	if last < 0 {
 		return adts.EmptyContainerElement{}, false
 	}
 
-	var lastElt adts.ContainerElement
-	if ss.backer.ThreadSafe {
-		ss.backer.Lock.Lock()
-		defer ss.backer.Lock.Unlock()
-		lastElt = ss.backer.Backer[len(ss.backer.Backer)-1]
-		if !ss.backer.RemoveAtIndex(len(ss.backer.Backer) - 1) {
-			return adts.EmptyContainerElement{}, false
-		}
-	} else {
-		lastElt = ss.backer.Backer[len(ss.backer.Backer)-1]
-		if !ss.backer.RemoveAtIndex(len(ss.backer.Backer) - 1) {
-			return adts.EmptyContainerElement{}, false
-		}
+	lastElt := ss.backer.Backer[last]
+	if !ss.backer.RemoveAtIndex(last) {
+		return adts.EmptyContainerElement{}, false
 	}
 
 	return lastElt, true
 }
+
+// -------------------------------------------------------
+// Capacity Methods
+// -------------------------------------------------------
+
+// Cap returns the capacity of the stack's backing slice.
+func (ss *SliceStack) Cap() int {
+	return ss.backer.Cap()
+}
+
+// Reserve grows the backing slice, if necessary, so that n more elements can
+// be added without reallocating. It returns false if the stack's
+// CapacityPolicy refuses to grow that far.
+func (ss *SliceStack) Reserve(n int) bool {
+	return ss.backer.Reserve(n)
+}
+
+// TrimToSize shrinks the backing slice so its capacity equals its length.
+func (ss *SliceStack) TrimToSize() {
+	ss.backer.TrimToSize()
+}
+
+// -------------------------------------------------------
+// Iterator Methods
+// -------------------------------------------------------
+
+// Iterator returns a fail-fast iterator over the stack from the top down.
+// Its Err returns adts.ErrConcurrentModification if the stack is changed
+// other than through the iterator's Remove.
+func (ss *SliceStack) Iterator() adts.MutableIterator {
+	return ss.backer.ReverseIterator()
+}
+
+// -------------------------------------------------------
+// Snapshot Methods
+// -------------------------------------------------------
+
+// Clone returns a copy of the stack with the same settings. Elements that
+// implement adts.Cloner are cloned.
+func (ss *SliceStack) Clone() *SliceStack {
+	return &SliceStack{ss.backer.Clone()}
+}
+
+// Snapshot returns a copy of the stack's elements from the top down. The
+// elements themselves aren't cloned.
+func (ss *SliceStack) Snapshot() []adts.ContainerElement {
+	elts := ss.backer.Snapshot()
+	for i, j := 0, len(elts)-1; i < j; i, j = i+1, j-1 {
+		elts[i], elts[j] = elts[j], elts[i]
+	}
+
+	return elts
+}
+
+// SnapshotIterator returns an iterator over a snapshot of the stack from the
+// top down. The lock (if threadsafe) is only held while the snapshot is
+// copied.
+func (ss *SliceStack) SnapshotIterator() adts.Iterator {
+	return adts.MakeSliceIterator(ss.Snapshot())
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock (if threadsafe), passing it a
+// non-threadsafe view of the stack. Changes made before fn returns an error
+// are kept.
+func (ss *SliceStack) Update(fn func(tx Stack) error) error {
+	return ss.backer.Update(func(tx *adts.SliceContainer) error {
+		return fn(&SliceStack{tx})
+	})
+}
+
+// View runs fn while holding the read lock (if threadsafe), passing it a
+// non-threadsafe view of the stack. fn must not modify the view.
+func (ss *SliceStack) View(fn func(tx Stack) error) error {
+	return ss.backer.View(func(tx *adts.SliceContainer) error {
+		return fn(&SliceStack{tx})
+	})
+}
+
+// Atomically runs fn as a single atomic operation on the stack.
+func (ss *SliceStack) Atomically(fn func(tx adts.Container) error) error {
+	return ss.Update(func(tx Stack) error {
+		return fn(tx)
+	})
+}
+
+// AddIfAbsent pushes the given element only if it isn't already in the
+// stack and returns whether it was added.
+func (ss *SliceStack) AddIfAbsent(item adts.ContainerElement) bool {
+	return ss.backer.AddIfAbsent(item)
+}
+
+// PopIf removes the top element from the stack only if it matches the given
+// predicate, and returns the element.
+func (ss *SliceStack) PopIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	var popped adts.ContainerElement = adts.EmptyContainerElement{}
+	ok := false
+	ss.backer.Update(func(tx *adts.SliceContainer) error {
+		last := len(tx.Backer) - 1
+		if last >= 0 && pred(tx.Backer[last]) {
+			popped, ok = (&SliceStack{tx}).popHelper()
+		}
+		return nil
+	})
+
+	return popped, ok
+}
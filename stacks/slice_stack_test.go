@@ -220,3 +220,107 @@ func TestSliceStackPop(t *testing.T) {
 		return
 	}
 }
+
+func TestSliceStackPopThreadSafe(t *testing.T) {
+	max := 1000
+	workers := 8
+
+	stack := MakeSliceStackThreadSafe()
+	for i := 0; i < max; i++ {
+		stack.Push(adts.IntElt(i))
+	}
+
+	// Every worker pops until the stack is empty. If the emptiness check
+	// and the removal aren't atomic then this either panics or loses elements.
+	counts := make(chan int, workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			count := 0
+			for {
+				if _, ok := stack.Pop(); !ok {
+					break
+				}
+				count++
+			}
+			counts <- count
+		}()
+	}
+
+	total := 0
+	for w := 0; w < workers; w++ {
+		total += <-counts
+	}
+
+	if total != max {
+		t.Errorf("Concurrent pops removed the wrong number of elements. Expected: %d, Actual: %d", max, total)
+	}
+	if !stack.IsEmpty() {
+		t.Errorf("Stack should be empty after all elements are popped.")
+	}
+}
+
+// -------------------------------------------------------
+// Test Atomic Methods
+// -------------------------------------------------------
+
+func TestSliceStackPopIf(t *testing.T) {
+	var a adts.Atomic
+	a = MakeSliceStackThreadSafe()
+
+	stack := a.(*SliceStack)
+	for i := 0; i < 10; i++ {
+		stack.Push(adts.IntElt(i))
+	}
+
+	even := func(elt adts.ContainerElement) bool {
+		return elt.(adts.IntElt)%2 == 0
+	}
+
+	if _, ok := stack.PopIf(even); ok {
+		t.Error("PopIf should not pop an element that doesn't match.")
+	}
+	stack.Pop()
+	if popped, ok := stack.PopIf(even); !ok || !popped.Equals(adts.IntElt(8)) {
+		t.Errorf("PopIf should pop a matching element. Expected: %v, Actual: %v", 8, popped)
+	}
+	if stack.Len() != 8 {
+		t.Errorf("Expected length: %d, Actual length: %d", 8, stack.Len())
+	}
+	if stack.AddIfAbsent(adts.IntElt(0)) || !stack.AddIfAbsent(adts.IntElt(20)) {
+		t.Error("AddIfAbsent should only add elements that aren't in the stack.")
+	}
+}
+
+func TestSliceStackCapacity(t *testing.T) {
+	c := NewSliceStack(adts.WithCapacityPolicy(adts.NeverShrink{}))
+
+	if !c.Reserve(50) || c.Cap() < 50 {
+		t.Errorf("Reserve didn't make room. Expected at least: %d, Actual: %d", 50, c.Cap())
+	}
+	for i := 0; i < 10; i++ {
+		c.Push(adts.IntElt(i))
+	}
+	for i := 0; i < 10; i++ {
+		c.Remove(adts.IntElt(i))
+	}
+	if c.Cap() < 50 {
+		t.Errorf("NeverShrink shouldn't shrink. Expected at least: %d, Actual: %d", 50, c.Cap())
+	}
+
+	c.TrimToSize()
+	if c.Cap() != 0 {
+		t.Errorf("TrimToSize should release the capacity. Expected: %d, Actual: %d", 0, c.Cap())
+	}
+}
+
+func TestSliceStackClone(t *testing.T) {
+	testStackClone(t, MakeSliceStackThreadSafe(), func(s Stack) Stack { return s.(*SliceStack).Clone() })
+}
+
+func TestSliceStackIterator(t *testing.T) {
+	testIterator(t, MakeSliceStack(), []int{4, 3, 2, 1, 0})
+}
+
+func TestSliceStackThreadSafeIterator(t *testing.T) {
+	testIterator(t, MakeSliceStackThreadSafe(), []int{4, 3, 2, 1, 0})
+}
@@ -15,3 +15,13 @@ type Stack interface {
 	// Add(item) bool
 	// Remove(item) bool
 }
+
+// firstEqual returns the first of the given functions, or nil if there are
+// none. Constructors use it to take an optional EqualFunc.
+func firstEqual(eq []adts.EqualFunc) adts.EqualFunc {
+	if len(eq) == 0 {
+		return nil
+	}
+
+	return eq[0]
+}
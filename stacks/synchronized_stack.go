@@ -0,0 +1,156 @@
+package stackadts
+
+import (
+	"sync"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+// SyncStack wraps any Stack so that every method call is guarded by a single RWMutex.
+// The wrapped stack should not be used directly once it has been wrapped.
+type SyncStack struct {
+	inner Stack
+	lock  *sync.RWMutex
+}
+
+// SynchronizedStack wraps the given stack so that it is safe for concurrent use.
+func SynchronizedStack(s Stack) *SyncStack {
+	return &SyncStack{s, &sync.RWMutex{}}
+}
+
+// WithLock runs fn while holding the write lock, passing it the wrapped
+// stack. This allows compound operations to run without another goroutine
+// seeing the intermediate state. fn must not call back into the SyncStack,
+// since that would deadlock.
+func (s *SyncStack) WithLock(fn func(Stack)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(s.inner)
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the stack.
+func (s *SyncStack) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Len()
+}
+
+// IsEmpty returns if the stack is empty or not.
+func (s *SyncStack) IsEmpty() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.IsEmpty()
+}
+
+// Clear removes all elements from the stack.
+func (s *SyncStack) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.inner.Clear()
+}
+
+// Contains returns true if the given item is in the stack.
+func (s *SyncStack) Contains(item adts.ContainerElement) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the stack.
+func (s *SyncStack) Add(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Add(item)
+}
+
+// Remove returns true if the given element was removed.
+func (s *SyncStack) Remove(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (s *SyncStack) RemoveElement(item adts.ContainerElement) (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return adts.RemoveElement(s.inner, item)
+}
+
+// -------------------------------------------------------
+// Stack Methods
+// -------------------------------------------------------
+
+// Push pushes the given element onto the top of the stack.
+func (s *SyncStack) Push(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Push(item)
+}
+
+// Pop removes the top element from the stack and returns the element.
+func (s *SyncStack) Pop() (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Pop()
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock, passing it the wrapped
+// stack. Changes made before fn returns an error are kept.
+func (s *SyncStack) Update(fn func(tx Stack) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// View runs fn while holding the read lock, passing it the wrapped stack.
+// fn must not modify the stack.
+func (s *SyncStack) View(fn func(tx Stack) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return fn(s.inner)
+}
+
+// Atomically runs fn as a single atomic operation on the wrapped stack.
+func (s *SyncStack) Atomically(fn func(tx adts.Container) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// AddIfAbsent adds the given element only if it isn't already in the
+// stack and returns whether it was added.
+func (s *SyncStack) AddIfAbsent(item adts.ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.inner.Contains(item) && s.inner.Add(item)
+}
+
+// PopIf removes the top element from the stack only if it matches the given
+// predicate, and returns the element.
+func (s *SyncStack) PopIf(pred adts.Predicate) (adts.ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The Stack interface has no way to peek, so pop the top element and
+	// push it back if it doesn't match.
+	top, ok := s.inner.Pop()
+	if !ok {
+		return adts.EmptyContainerElement{}, false
+	}
+	if !pred(top) {
+		s.inner.Push(top)
+		return adts.EmptyContainerElement{}, false
+	}
+
+	return top, true
+}
@@ -0,0 +1,90 @@
+package stackadts
+
+import (
+	"sync"
+	"testing"
+
+	adts "github.com/johnsrd7/go-adts"
+)
+
+func TestSynchronizedStack(t *testing.T) {
+	var s Stack
+	s = SynchronizedStack(MakeListStack())
+
+	for i := 0; i < 100; i++ {
+		s.Push(adts.IntElt(i))
+	}
+
+	for i := 0; i < 100; i++ {
+		popped, ok := s.Pop()
+		if !ok || !popped.Equals(adts.IntElt(100-i-1)) {
+			t.Errorf("Pop didn't return the proper element. Expected: %v, Actual: %v", 100-i-1, popped)
+			return
+		}
+	}
+
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop should not succeed for an empty stack.")
+	}
+}
+
+func TestSynchronizedStackWithLock(t *testing.T) {
+	s := SynchronizedStack(MakeSliceStack())
+	for i := 0; i < 1000; i++ {
+		s.Push(adts.IntElt(i))
+	}
+
+	// Pop in pairs. Since both pops happen under the same lock, every pair
+	// should be adjacent values.
+	var wg sync.WaitGroup
+	errs := make(chan string, 8)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				done := false
+				s.WithLock(func(inner Stack) {
+					if inner.Len() < 2 {
+						done = true
+						return
+					}
+					first, _ := inner.Pop()
+					second, _ := inner.Pop()
+					if !second.Equals(first.(adts.IntElt) - 1) {
+						errs <- "popped pair was not adjacent"
+						done = true
+					}
+				})
+				if done {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if !s.IsEmpty() {
+		t.Errorf("Stack should be empty after all pairs are popped. Actual length: %d", s.Len())
+	}
+}
+
+func TestSynchronizedStackPopIf(t *testing.T) {
+	var a adts.Atomic
+	a = SynchronizedStack(MakeSliceStack())
+
+	s := a.(*SyncStack)
+	s.Push(adts.IntElt(1))
+	s.Push(adts.IntElt(2))
+
+	if _, ok := s.PopIf(func(elt adts.ContainerElement) bool { return elt.Equals(adts.IntElt(1)) }); ok {
+		t.Error("PopIf should not pop an element that doesn't match.")
+	}
+	if popped, ok := s.PopIf(func(elt adts.ContainerElement) bool { return elt.Equals(adts.IntElt(2)) }); !ok || !popped.Equals(adts.IntElt(2)) {
+		t.Errorf("PopIf should pop a matching element. Expected: %v, Actual: %v", 2, popped)
+	}
+}
@@ -0,0 +1,117 @@
+package adts
+
+import (
+	"sync"
+)
+
+// SyncContainer wraps any Container so that every method call is guarded by
+// a single RWMutex. The wrapped container should not be used directly once it
+// has been wrapped.
+type SyncContainer struct {
+	inner Container
+	lock  *sync.RWMutex
+}
+
+// Synchronized wraps the given container so that it is safe for concurrent use.
+func Synchronized(c Container) *SyncContainer {
+	return &SyncContainer{c, &sync.RWMutex{}}
+}
+
+// WithLock runs fn while holding the write lock, passing it the wrapped
+// container. This allows compound operations (e.g. Contains followed by Add)
+// to run without another goroutine seeing the intermediate state. fn must not
+// call back into the SyncContainer, since that would deadlock.
+func (s *SyncContainer) WithLock(fn func(Container)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn(s.inner)
+}
+
+// -------------------------------------------------------
+// Container Methods
+// -------------------------------------------------------
+
+// Len returns the number of elements in the container.
+func (s *SyncContainer) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Len()
+}
+
+// IsEmpty returns if the container is empty or not.
+func (s *SyncContainer) IsEmpty() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.IsEmpty()
+}
+
+// Clear removes all elements from the container.
+func (s *SyncContainer) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.inner.Clear()
+}
+
+// Contains returns true if the given item is in the container.
+func (s *SyncContainer) Contains(item ContainerElement) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.inner.Contains(item)
+}
+
+// Add returns true if the given element was added to the container.
+func (s *SyncContainer) Add(item ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Add(item)
+}
+
+// Remove returns true if the given element was removed.
+func (s *SyncContainer) Remove(item ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.inner.Remove(item)
+}
+
+// RemoveElement removes the first element equal to the given item and
+// returns it, which can differ from item under a custom EqualFunc.
+func (s *SyncContainer) RemoveElement(item ContainerElement) (ContainerElement, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return RemoveElement(s.inner, item)
+}
+
+// -------------------------------------------------------
+// Atomic Methods
+// -------------------------------------------------------
+
+// Update runs fn while holding the write lock, passing it the wrapped
+// container. Changes made before fn returns an error are kept.
+func (s *SyncContainer) Update(fn func(tx Container) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// View runs fn while holding the read lock, passing it the wrapped container.
+// fn must not modify the container.
+func (s *SyncContainer) View(fn func(tx Container) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return fn(s.inner)
+}
+
+// Atomically runs fn as a single atomic operation on the wrapped container.
+func (s *SyncContainer) Atomically(fn func(tx Container) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.inner)
+}
+
+// AddIfAbsent adds the given element only if it isn't already in the
+// container and returns whether it was added.
+func (s *SyncContainer) AddIfAbsent(item ContainerElement) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return !s.inner.Contains(item) && s.inner.Add(item)
+}
@@ -0,0 +1,60 @@
+package adts
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSynchronized(t *testing.T) {
+	var c Container
+	c = Synchronized(MakeSliceContainer())
+
+	if !c.IsEmpty() {
+		t.Error("Wrapped empty container should be empty.")
+	}
+
+	for i := 0; i < 100; i++ {
+		c.Add(IntElt(i))
+	}
+
+	if c.Len() != 100 {
+		t.Errorf("Expected length: %d, Actual length: %d", 100, c.Len())
+	}
+	if !c.Contains(IntElt(50)) {
+		t.Error("Contains failed to find a value added through the wrapper.")
+	}
+	if !c.Remove(IntElt(50)) || c.Contains(IntElt(50)) {
+		t.Error("Remove failed to remove a value through the wrapper.")
+	}
+
+	c.Clear()
+	if !c.IsEmpty() {
+		t.Error("Container should be empty after call to Clear.")
+	}
+}
+
+func TestSynchronizedWithLock(t *testing.T) {
+	c := Synchronized(MakeSliceContainer())
+
+	// Every goroutine tries to add the same values if they're absent. Since the
+	// check and the add happen under one lock, each value should be added once.
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				c.WithLock(func(inner Container) {
+					if !inner.Contains(IntElt(i)) {
+						inner.Add(IntElt(i))
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.Len() != 100 {
+		t.Errorf("Expected length: %d, Actual length: %d", 100, c.Len())
+	}
+}
@@ -0,0 +1,208 @@
+package adts
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrSameContainer is returned when asked to transfer elements from a
+// container to itself.
+var ErrSameContainer = errors.New("adts: source and destination are the same container")
+
+// ErrNotFound is returned when the element to transfer isn't in the source container.
+var ErrNotFound = errors.New("adts: element not found in source container")
+
+// ErrNotEnoughElements is returned when the source container has fewer
+// elements than were asked to be moved.
+var ErrNotEnoughElements = errors.New("adts: not enough elements in source container")
+
+// ErrRejected is returned when the destination container refuses to add an
+// element.
+var ErrRejected = errors.New("adts: destination container rejected an element")
+
+// ErrNegativeCount is returned by MoveN when asked to move fewer than zero
+// elements.
+var ErrNegativeCount = errors.New("adts: negative number of elements to move")
+
+// ErrNoLockOrder is returned when a container passed to Transfer or MoveN
+// isn't a pointer, so it has no LockOrder key to lock it by.
+var ErrNoLockOrder = errors.New("adts: container has no lock order (it isn't a pointer)")
+
+// ErrNotRestorable is returned when Transfer or MoveN is given a container
+// whose transaction view has no Snapshot method, so a rejected Add couldn't
+// be undone.
+var ErrNotRestorable = errors.New("adts: container can't be restored (it has no Snapshot)")
+
+// ErrNotTakeable is returned by MoveN when the source container has no
+// notion of a next element (it isn't a list, stack or queue).
+var ErrNotTakeable = errors.New("adts: source container has no next element to take")
+
+// LockOrder returns the key used to order lock acquisition across containers.
+// Whenever two containers need to be locked at once, the one with the lower
+// key is locked first, which prevents two goroutines that lock the same pair
+// in opposite directions from deadlocking. Containers that aren't pointers
+// have no identity to order by and all get the key 0, so Transfer and MoveN
+// reject them.
+func LockOrder(c Container) uintptr {
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Ptr {
+		return 0
+	}
+
+	return v.Pointer()
+}
+
+// atomicPair runs fn with both containers' locks held, acquiring them in
+// LockOrder order. fn is always passed the views in (src, dst) order.
+func atomicPair(src, dst Atomic, fn func(srcTx, dstTx Container) error) error {
+	srcKey, dstKey := LockOrder(src), LockOrder(dst)
+	if srcKey == 0 || dstKey == 0 {
+		return ErrNoLockOrder
+	}
+	if srcKey == dstKey {
+		return ErrSameContainer
+	}
+
+	if dstKey < srcKey {
+		return dst.Atomically(func(dstTx Container) error {
+			return src.Atomically(func(srcTx Container) error {
+				return fn(srcTx, dstTx)
+			})
+		})
+	}
+
+	return src.Atomically(func(srcTx Container) error {
+		return dst.Atomically(func(dstTx Container) error {
+			return fn(srcTx, dstTx)
+		})
+	})
+}
+
+// Transfer atomically removes the first element of src equal to item and
+// adds the removed element, which can differ from item under a custom
+// EqualFunc, to dst. Either the element is moved or neither container is
+// changed.
+func Transfer(src, dst Atomic, item ContainerElement) error {
+	return atomicPair(src, dst, func(srcTx, dstTx Container) error {
+		snap, ok := srcTx.(Snapshotter)
+		if !ok {
+			return ErrNotRestorable
+		}
+		if !srcTx.Contains(item) {
+			return ErrNotFound
+		}
+
+		before := snap.Snapshot()
+		stored, _ := RemoveElement(srcTx, item)
+		if !dstTx.Add(stored) {
+			restore(srcTx, before)
+			return ErrRejected
+		}
+
+		return nil
+	})
+}
+
+// MoveN atomically takes the next n elements from src and adds them to dst.
+// The next element is the one Dequeue returns for queues, the one Pop returns
+// for stacks and the first element for lists. Either all n elements are
+// moved or neither container is changed: if dst rejects an Add (a slice
+// container under MemoryBudget does once it's full), both are rebuilt from
+// their snapshots and ErrRejected is returned. A negative n returns
+// ErrNegativeCount.
+func MoveN(src, dst Atomic, n int) error {
+	if n < 0 {
+		return ErrNegativeCount
+	}
+
+	return atomicPair(src, dst, func(srcTx, dstTx Container) error {
+		if srcTx.Len() < n {
+			return ErrNotEnoughElements
+		}
+
+		take := takeFunc(srcTx)
+		if take == nil {
+			return ErrNotTakeable
+		}
+		srcSnap, srcOk := srcTx.(Snapshotter)
+		dstSnap, dstOk := dstTx.(Snapshotter)
+		if !srcOk || !dstOk {
+			return ErrNotRestorable
+		}
+
+		dstLen := dstTx.Len()
+		taken := make([]ContainerElement, 0, n)
+		for i := 0; i < n; i++ {
+			elt, _ := take()
+			taken = append(taken, elt)
+			if !dstTx.Add(elt) {
+				// The taken elements were next in src, so they go back in
+				// front of the rest. dst keeps only what it had before.
+				restore(srcTx, append(taken, srcSnap.Snapshot()...))
+				kept := dstSnap.Snapshot()
+				if isStack(dstTx) {
+					kept = kept[len(kept)-dstLen:]
+				} else {
+					kept = kept[:dstLen]
+				}
+				restore(dstTx, kept)
+				return ErrRejected
+			}
+		}
+
+		return nil
+	})
+}
+
+// restore empties the container and refills it with the given elements,
+// next one first (the order Snapshot returns them in). Stacks push what's
+// added, so their elements are added bottom up.
+func restore(c Container, elts []ContainerElement) {
+	c.Clear()
+	if isStack(c) {
+		for i := len(elts) - 1; i >= 0; i-- {
+			c.Add(elts[i])
+		}
+		return
+	}
+
+	for _, elt := range elts {
+		c.Add(elt)
+	}
+}
+
+// isStack returns true if the container adds elements on top, where Pop
+// takes them from.
+func isStack(c Container) bool {
+	_, ok := c.(interface {
+		Pop() (ContainerElement, bool)
+	})
+	return ok
+}
+
+// takeFunc returns a function that removes and returns the next element of
+// the given container, or nil if the container has no notion of a next
+// element. Queues, stacks and lists live in packages that import this one, so
+// they're matched by their methods.
+func takeFunc(c Container) func() (ContainerElement, bool) {
+	switch tc := c.(type) {
+	case interface {
+		Dequeue() (ContainerElement, bool)
+	}:
+		return tc.Dequeue
+	case interface {
+		Pop() (ContainerElement, bool)
+	}:
+		return tc.Pop
+	case interface {
+		Get(int) ContainerElement
+	}:
+		return func() (ContainerElement, bool) {
+			// Remove deletes the first matching element, which is the one at index 0.
+			first := tc.Get(0)
+			return first, c.Remove(first)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package adts
+
+import (
+	"sync"
+	"testing"
+)
+
+// dequeueContainer is a SliceContainer with queue semantics, so MoveN has a
+// notion of the next element without importing queueadts.
+type dequeueContainer struct {
+	*SliceContainer
+}
+
+func (dc *dequeueContainer) Dequeue() (ContainerElement, bool) {
+	if len(dc.Backer) == 0 {
+		return EmptyContainerElement{}, false
+	}
+
+	first := dc.Backer[0]
+	return first, dc.RemoveAtIndex(0)
+}
+
+func (dc *dequeueContainer) Atomically(fn func(tx Container) error) error {
+	return dc.Update(func(tx *SliceContainer) error {
+		return fn(&dequeueContainer{tx})
+	})
+}
+
+func TestTransfer(t *testing.T) {
+	src := MakeSliceContainerThreadSafe()
+	dst := MakeSliceContainerThreadSafe()
+	for i := 0; i < 10; i++ {
+		src.Add(IntElt(i))
+	}
+
+	if err := Transfer(src, dst, IntElt(3)); err != nil {
+		t.Errorf("Transfer of an existing element failed: %v", err)
+	}
+	if src.Contains(IntElt(3)) || !dst.Contains(IntElt(3)) {
+		t.Error("Transfer didn't move the element.")
+	}
+	if err := Transfer(src, dst, IntElt(3)); err != ErrNotFound {
+		t.Errorf("Transfer of a missing element should return ErrNotFound. Actual: %v", err)
+	}
+	if err := Transfer(src, src, IntElt(4)); err != ErrSameContainer {
+		t.Errorf("Transfer to the same container should return ErrSameContainer. Actual: %v", err)
+	}
+}
+
+func TestMoveN(t *testing.T) {
+	src := &dequeueContainer{MakeSliceContainerThreadSafe()}
+	dst := MakeSliceContainerThreadSafe()
+	for i := 0; i < 10; i++ {
+		src.Add(IntElt(i))
+	}
+
+	if err := MoveN(src, dst, 11); err != ErrNotEnoughElements {
+		t.Errorf("MoveN of too many elements should return ErrNotEnoughElements. Actual: %v", err)
+	}
+	if src.Len() != 10 || dst.Len() != 0 {
+		t.Error("A failed MoveN should not change either container.")
+	}
+
+	if err := MoveN(src, dst, 4); err != nil {
+		t.Errorf("MoveN failed: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if !dst.Backer[i].Equals(IntElt(i)) {
+			t.Errorf("MoveN moved the wrong elements. Expected: %v, Actual: %v", i, dst.Backer[i])
+		}
+	}
+	if src.Len() != 6 {
+		t.Errorf("Expected length: %d, Actual length: %d", 6, src.Len())
+	}
+
+	if err := MoveN(src, dst, -1); err != ErrNegativeCount {
+		t.Errorf("MoveN of a negative count should return ErrNegativeCount. Actual: %v", err)
+	}
+	if src.Len() != 6 || dst.Len() != 4 {
+		t.Error("A negative MoveN should not change either container.")
+	}
+
+	if err := MoveN(dst, src, 1); err != ErrNotTakeable {
+		t.Errorf("MoveN from a plain container should return ErrNotTakeable. Actual: %v", err)
+	}
+}
+
+// rejectingContainer is a SliceContainer that refuses every Add.
+type rejectingContainer struct {
+	*SliceContainer
+}
+
+func (rc *rejectingContainer) Add(ContainerElement) bool {
+	return false
+}
+
+func (rc *rejectingContainer) Atomically(fn func(tx Container) error) error {
+	return rc.Update(func(tx *SliceContainer) error {
+		return fn(&rejectingContainer{tx})
+	})
+}
+
+// valueContainer is an Atomic that isn't a pointer.
+type valueContainer struct {
+	*SliceContainer
+}
+
+func (vc valueContainer) Atomically(fn func(tx Container) error) error {
+	return vc.SliceContainer.Atomically(fn)
+}
+
+func TestTransferRejected(t *testing.T) {
+	src := MakeSliceContainerThreadSafe()
+	for i := 0; i < 3; i++ {
+		src.Add(IntElt(i))
+	}
+
+	if err := Transfer(src, &rejectingContainer{MakeSliceContainer()}, IntElt(0)); err != ErrRejected {
+		t.Errorf("Transfer to a rejecting container should return ErrRejected. Actual: %v", err)
+	}
+	for i, elt := range src.Backer {
+		if !elt.Equals(IntElt(i)) {
+			t.Errorf("A rejected Transfer should leave src unchanged. Expected: %v, Actual: %v", i, elt)
+		}
+	}
+}
+
+func TestTransferNoLockOrder(t *testing.T) {
+	a := valueContainer{MakeSliceContainerThreadSafe()}
+	b := valueContainer{MakeSliceContainerThreadSafe()}
+	a.Add(IntElt(1))
+
+	if LockOrder(a) != 0 {
+		t.Errorf("Non-pointer containers should have no lock order. Actual: %v", LockOrder(a))
+	}
+	if err := Transfer(a, b, IntElt(1)); err != ErrNoLockOrder {
+		t.Errorf("Transfer between non-pointer containers should return ErrNoLockOrder. Actual: %v", err)
+	}
+	if err := MoveN(a, MakeSliceContainerThreadSafe(), 0); err != ErrNoLockOrder {
+		t.Errorf("MoveN from a non-pointer container should return ErrNoLockOrder. Actual: %v", err)
+	}
+}
+
+func TestTransferEqualFunc(t *testing.T) {
+	src := MakeSliceContainerThreadSafe(lastDigit)
+	dst := MakeSliceContainerThreadSafe()
+	src.Add(IntElt(13))
+
+	if err := Transfer(src, dst, IntElt(3)); err != nil {
+		t.Errorf("Transfer of an equal element failed: %v", err)
+	}
+	if src.Len() != 0 || dst.Len() != 1 || !dst.Backer[0].Equals(IntElt(13)) {
+		t.Errorf("Transfer should move the stored element. Expected: %d, Actual: %v", 13, dst.Backer)
+	}
+}
+
+// popContainer is a SliceContainer with stack semantics. The end of Backer
+// is the top, so Snapshot returns it reversed.
+type popContainer struct {
+	*SliceContainer
+}
+
+func (pc *popContainer) Pop() (ContainerElement, bool) {
+	if len(pc.Backer) == 0 {
+		return EmptyContainerElement{}, false
+	}
+
+	last := pc.Backer[len(pc.Backer)-1]
+	return last, pc.RemoveAtIndex(len(pc.Backer) - 1)
+}
+
+func (pc *popContainer) Snapshot() []ContainerElement {
+	elts := make([]ContainerElement, 0, len(pc.Backer))
+	for i := len(pc.Backer) - 1; i >= 0; i-- {
+		elts = append(elts, pc.Backer[i])
+	}
+
+	return elts
+}
+
+func (pc *popContainer) Atomically(fn func(tx Container) error) error {
+	return pc.Update(func(tx *SliceContainer) error {
+		return fn(&popContainer{tx})
+	})
+}
+
+// checkBacker checks the container holds exactly the given ints in order.
+func checkBacker(t *testing.T, name string, sc *SliceContainer, expected ...int) {
+	t.Helper()
+
+	if len(sc.Backer) != len(expected) {
+		t.Fatalf("%s has the wrong elements. Expected: %v, Actual: %v", name, expected, sc.Backer)
+	}
+	for i, elt := range sc.Backer {
+		if !elt.Equals(IntElt(expected[i])) {
+			t.Fatalf("%s has the wrong elements. Expected: %v, Actual: %v", name, expected, sc.Backer)
+		}
+	}
+}
+
+func TestMoveNRejected(t *testing.T) {
+	// dst has room for two more, and already holds an element equal to one
+	// of the moved ones.
+	src := &dequeueContainer{MakeSliceContainer()}
+	dst := NewSliceContainer(WithCapacityPolicy(MemoryBudget{MaxElements: 4}))
+	for i := 0; i < 6; i++ {
+		src.Add(IntElt(i))
+	}
+	dst.Add(IntElt(1))
+	dst.Add(IntElt(9))
+
+	if err := MoveN(src, dst, 3); err != ErrRejected {
+		t.Errorf("MoveN into a full container should return ErrRejected. Actual: %v", err)
+	}
+	checkBacker(t, "src", src.SliceContainer, 0, 1, 2, 3, 4, 5)
+	checkBacker(t, "dst", dst, 1, 9)
+
+	// Stacks take from and add to the top.
+	stackSrc := &popContainer{MakeSliceContainer()}
+	stackDst := &popContainer{NewSliceContainer(WithCapacityPolicy(MemoryBudget{MaxElements: 3}))}
+	for i := 0; i < 5; i++ {
+		stackSrc.Add(IntElt(i))
+	}
+	stackDst.Add(IntElt(7))
+
+	if err := MoveN(stackSrc, stackDst, 3); err != ErrRejected {
+		t.Errorf("MoveN onto a full stack should return ErrRejected. Actual: %v", err)
+	}
+	checkBacker(t, "stack src", stackSrc.SliceContainer, 0, 1, 2, 3, 4)
+	checkBacker(t, "stack dst", stackDst.SliceContainer, 7)
+}
+
+// opaqueContainer hides every method but the Container ones, so it has no
+// Snapshot.
+type opaqueContainer struct {
+	Container
+}
+
+// opaqueAtomic passes opaque views of its container to Atomically.
+type opaqueAtomic struct {
+	*dequeueContainer
+}
+
+func (oa *opaqueAtomic) Atomically(fn func(tx Container) error) error {
+	return oa.dequeueContainer.Atomically(func(tx Container) error {
+		return fn(opaqueContainer{tx})
+	})
+}
+
+func TestTransferNotRestorable(t *testing.T) {
+	src := &opaqueAtomic{&dequeueContainer{MakeSliceContainer()}}
+	src.Add(IntElt(1))
+
+	if err := Transfer(src, MakeSliceContainerThreadSafe(), IntElt(1)); err != ErrNotRestorable {
+		t.Errorf("Transfer from a container without Snapshot should return ErrNotRestorable. Actual: %v", err)
+	}
+	if src.Len() != 1 {
+		t.Error("A refused Transfer should leave src unchanged.")
+	}
+}
+
+func TestMoveNOppositeDirections(t *testing.T) {
+	a := &dequeueContainer{MakeSliceContainerThreadSafe()}
+	b := &dequeueContainer{MakeSliceContainerThreadSafe()}
+	for i := 0; i < 100; i++ {
+		a.Add(IntElt(i))
+		b.Add(IntElt(i))
+	}
+
+	// Moving in opposite directions at the same time would deadlock if the
+	// locks weren't acquired in a consistent order.
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				MoveN(a, b, 1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				MoveN(b, a, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if a.Len()+b.Len() != 200 {
+		t.Errorf("Elements were lost or duplicated. Expected total: %d, Actual: %d", 200, a.Len()+b.Len())
+	}
+}
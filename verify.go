@@ -0,0 +1,253 @@
+package adts
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// Hashable is implemented by elements that can be hashed. Elements that are
+// equal must have the same hash.
+type Hashable interface {
+	Hash() uint64
+
+	ContainerElement
+}
+
+// Ordered is implemented by elements that have a total order. Compare returns
+// a negative number, zero or a positive number when the element is less
+// than, equal to or greater than the other element, and must return zero
+// exactly when Equals returns true.
+type Ordered interface {
+	Compare(ContainerElement) int
+
+	ContainerElement
+}
+
+// Violation describes an element contract law that doesn't hold, along with
+// the elements that break it.
+type Violation struct {
+	Law      string
+	Elements []ContainerElement
+	Detail   string
+}
+
+// Error returns a readable description of the violation.
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s violated by %v: %s", v.Law, v.Elements, v.Detail)
+}
+
+// foreignElement is a type no other package knows about, used to check that
+// Equals and Compare cope with elements of an unexpected type.
+type foreignElement struct{}
+
+func (fe foreignElement) Equals(other ContainerElement) bool {
+	_, ok := other.(foreignElement)
+	return ok
+}
+
+// VerifyElements checks the ContainerElement contract against n samples drawn
+// from gen and returns every law that was violated. The laws are:
+//
+//   - Reflexivity: a.Equals(a).
+//   - Symmetry: a.Equals(b) == b.Equals(a).
+//   - Transitivity: a.Equals(b) and b.Equals(c) imply a.Equals(c).
+//   - Consistency: repeated calls give the same answer.
+//   - Hash: equal Hashable elements have equal hashes, and Hash doesn't
+//     panic.
+//   - Compare: Ordered elements compare to zero exactly when equal, and
+//     Compare is antisymmetric and transitive.
+//   - Foreign: Equals (and Compare) don't panic when given nil,
+//     EmptyContainerElement or an element of an unknown type, and Equals
+//     returns false for them.
+//
+// Only the first violation of each law is reported, with the samples that
+// showed it first. The samples aren't minimized.
+func VerifyElements(gen func(r *rand.Rand) ContainerElement, n int, seed int64) []Violation {
+	r := rand.New(rand.NewSource(seed))
+	samples := make([]ContainerElement, n)
+	for i := range samples {
+		samples[i] = gen(r)
+	}
+
+	// Every sample is also paired with a freshly generated copy of itself
+	// from the same seed, so there are always some equal pairs to check.
+	r = rand.New(rand.NewSource(seed))
+	for i := 0; i < n; i++ {
+		samples = append(samples, gen(r))
+	}
+
+	return VerifySamples(samples)
+}
+
+// VerifySamples checks the ContainerElement contract (see VerifyElements)
+// against the given samples.
+func VerifySamples(samples []ContainerElement) []Violation {
+	found := map[string]bool{}
+	violations := []Violation{}
+	report := func(law, detail string, elts ...ContainerElement) {
+		if !found[law] {
+			found[law] = true
+			violations = append(violations, Violation{law, elts, detail})
+		}
+	}
+
+	for _, a := range samples {
+		for _, foreign := range []ContainerElement{nil, EmptyContainerElement{}, foreignElement{}} {
+			if foreign != nil && reflect.TypeOf(a) == reflect.TypeOf(foreign) {
+				continue
+			}
+			eq, err := safeEquals(a, foreign)
+			if err != nil {
+				report("Foreign", fmt.Sprintf("Equals(%#v) panicked: %v", foreign, err), a)
+			} else if eq {
+				report("Foreign", fmt.Sprintf("Equals(%#v) returned true", foreign), a)
+			}
+			if oa, ok := a.(Ordered); ok {
+				if _, err := safeCompare(oa, foreign); err != nil {
+					report("Foreign", fmt.Sprintf("Compare(%#v) panicked: %v", foreign, err), a)
+				}
+			}
+		}
+
+		if eq, err := safeEquals(a, a); err != nil || !eq {
+			report("Reflexivity", "a.Equals(a) is not true", a)
+		}
+		if ha, ok := a.(Hashable); ok {
+			if _, err := safeHash(ha); err != nil {
+				report("Hash", fmt.Sprintf("Hash panicked: %v", err), a)
+			}
+		}
+	}
+
+	for i, a := range samples {
+		for _, b := range samples[i+1:] {
+			ab, err1 := safeEquals(a, b)
+			ba, err2 := safeEquals(b, a)
+			if err1 != nil || err2 != nil {
+				report("Foreign", "Equals panicked", a, b)
+				continue
+			}
+			if ab != ba {
+				report("Symmetry", fmt.Sprintf("a.Equals(b) = %v but b.Equals(a) = %v", ab, ba), a, b)
+			}
+			if again, _ := safeEquals(a, b); again != ab {
+				report("Consistency", "a.Equals(b) changed between calls", a, b)
+			}
+
+			if ha, ok := a.(Hashable); ok && ab {
+				if hb, ok := b.(Hashable); ok {
+					hashA, err1 := safeHash(ha)
+					hashB, err2 := safeHash(hb)
+					if err1 == nil && err2 == nil && hashA != hashB {
+						report("Hash", fmt.Sprintf("equal elements hash to %d and %d", hashA, hashB), a, b)
+					}
+				}
+			}
+
+			oa, okA := a.(Ordered)
+			ob, okB := b.(Ordered)
+			if okA && okB {
+				cab, err1 := safeCompare(oa, b)
+				cba, err2 := safeCompare(ob, a)
+				switch {
+				case err1 != nil || err2 != nil:
+					report("Compare", "Compare panicked", a, b)
+				case (cab == 0) != ab:
+					report("Compare", fmt.Sprintf("Compare = %d but Equals = %v", cab, ab), a, b)
+				case sign(cab) != -sign(cba):
+					report("Compare", fmt.Sprintf("a.Compare(b) = %d but b.Compare(a) = %d", cab, cba), a, b)
+				}
+			}
+		}
+	}
+
+	// Transitivity is cubic, so it's only checked on triples that could
+	// break it: a equals b and b equals c (or a < b and b < c).
+	for i, a := range samples {
+		for j, b := range samples {
+			if i == j {
+				continue
+			}
+			ab, _ := safeEquals(a, b)
+			abCmp, aOrdered := compareIfOrdered(a, b)
+			if !ab && !(aOrdered && abCmp < 0) {
+				continue
+			}
+			for k, c := range samples {
+				if k == i || k == j {
+					continue
+				}
+				if bc, _ := safeEquals(b, c); ab && bc {
+					if ac, _ := safeEquals(a, c); !ac {
+						report("Transitivity", "a.Equals(b) and b.Equals(c) but not a.Equals(c)", a, b, c)
+					}
+				}
+				if bcCmp, ok := compareIfOrdered(b, c); aOrdered && ok && abCmp < 0 && bcCmp < 0 {
+					if acCmp, _ := compareIfOrdered(a, c); acCmp >= 0 {
+						report("Compare", "a < b and b < c but not a < c", a, b, c)
+					}
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// safeEquals calls a.Equals(b), turning a panic into an error.
+func safeEquals(a, b ContainerElement) (eq bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%v", p)
+		}
+	}()
+
+	return a.Equals(b), nil
+}
+
+// safeCompare calls a.Compare(b), turning a panic into an error.
+func safeCompare(a Ordered, b ContainerElement) (cmp int, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%v", p)
+		}
+	}()
+
+	return a.Compare(b), nil
+}
+
+// safeHash calls h.Hash(), turning a panic into an error.
+func safeHash(h Hashable) (hash uint64, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%v", p)
+		}
+	}()
+
+	return h.Hash(), nil
+}
+
+// compareIfOrdered compares a and b if they're both Ordered.
+func compareIfOrdered(a, b ContainerElement) (int, bool) {
+	oa, okA := a.(Ordered)
+	_, okB := b.(Ordered)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	cmp, err := safeCompare(oa, b)
+	return cmp, err == nil
+}
+
+// sign returns -1, 0 or 1 depending on the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+
+	return 0
+}
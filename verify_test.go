@@ -0,0 +1,94 @@
+package adts
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVerifyElementsIntElt(t *testing.T) {
+	gen := func(r *rand.Rand) ContainerElement {
+		return IntElt(r.Intn(10))
+	}
+
+	if violations := VerifyElements(gen, 50, 99); len(violations) != 0 {
+		t.Errorf("IntElt should satisfy the element contract. Violations: %v", violations)
+	}
+
+	if violations := VerifySamples([]ContainerElement{EmptyContainerElement{}, IntElt(0)}); len(violations) != 0 {
+		t.Errorf("EmptyContainerElement should satisfy the element contract. Violations: %v", violations)
+	}
+}
+
+// lenientElt thinks it's equal to any IntElt with the same value, but
+// IntElt doesn't agree.
+type lenientElt int
+
+func (le lenientElt) Equals(other ContainerElement) bool {
+	switch o := other.(type) {
+	case lenientElt:
+		return le == o
+	case IntElt:
+		return int(le) == int(o)
+	}
+	return false
+}
+
+// panickyElt assumes every element is a panickyElt.
+type panickyElt int
+
+func (pe panickyElt) Equals(other ContainerElement) bool {
+	return pe == other.(panickyElt)
+}
+
+// badHashElt is equal to every other badHashElt but hashes differently.
+type badHashElt int
+
+func (bh badHashElt) Equals(other ContainerElement) bool {
+	_, ok := other.(badHashElt)
+	return ok
+}
+
+func (bh badHashElt) Hash() uint64 {
+	return uint64(bh)
+}
+
+// panickyHashElt has a well-behaved Equals but its Hash panics.
+type panickyHashElt int
+
+func (ph panickyHashElt) Equals(other ContainerElement) bool {
+	o, ok := other.(panickyHashElt)
+	return ok && ph == o
+}
+
+func (ph panickyHashElt) Hash() uint64 {
+	panic("no hash")
+}
+
+func TestVerifySamplesViolations(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []ContainerElement
+		law     string
+	}{
+		{"Symmetry", []ContainerElement{IntElt(1), lenientElt(1)}, "Symmetry"},
+		{"Foreign", []ContainerElement{panickyElt(1)}, "Foreign"},
+		{"Hash", []ContainerElement{badHashElt(1), badHashElt(2)}, "Hash"},
+		{"PanickyHash", []ContainerElement{panickyHashElt(1), panickyHashElt(1)}, "Hash"},
+	}
+
+	for _, test := range tests {
+		violations := VerifySamples(test.samples)
+		found := false
+		for _, v := range violations {
+			if v.Law == test.law {
+				found = true
+				if v.Error() == "" {
+					t.Errorf("%s: violation should have a description.", test.name)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected a %s violation. Actual: %v", test.name, test.law, violations)
+		}
+	}
+}